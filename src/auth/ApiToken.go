@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// ApiTokenPrefix starts every API token, which tells them apart from JWTs.
+const ApiTokenPrefix = "jobs_"
+
+// ApiTokenVerifier verifies the API tokens companies create for their own
+// applications.
+type ApiTokenVerifier interface {
+	Verify(token string) (*Identity, error)
+}
+
+// IsApiToken tells whether the bearer token is an API token rather than a JWT.
+func IsApiToken(token string) bool {
+	return strings.HasPrefix(token, ApiTokenPrefix)
+}
+
+// NewApiToken returns a new random API token.
+func NewApiToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return ApiTokenPrefix + hex.EncodeToString(secret), nil
+}
+
+// HashApiToken returns the hash API tokens are stored and looked up by. The
+// tokens are random, so they do not need a slow password hash.
+func HashApiToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var ErrInvalidToken = errors.New("Invalid token")
+
+// Claims are the claims of the tokens issued to users. The subject is the id
+// of the user.
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles     []string `json:"roles"`
+	Companies []int    `json:"companies"`
+}
+
+// Authenticator verifies bearer tokens, which have to expire. Issuer and
+// Audience are only checked when set.
+type Authenticator struct {
+	Keys     *KeySet
+	Issuer   string
+	Audience string
+}
+
+// Authenticate verifies the token and returns the identity it was issued to.
+func (authenticator *Authenticator) Authenticate(token string) (*Identity, error) {
+	if authenticator.Keys == nil {
+		return nil, fmt.Errorf("%w: no signing keys are configured", ErrInvalidToken)
+	}
+
+	claims := Claims{}
+	if _, err := jwt.ParseWithClaims(token, &claims, authenticator.Keys.Key); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: token does not expire", ErrInvalidToken)
+	}
+	if authenticator.Issuer != "" && !claims.VerifyIssuer(authenticator.Issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer %s", ErrInvalidToken, claims.Issuer)
+	}
+	if authenticator.Audience != "" && !claims.VerifyAudience(authenticator.Audience, true) {
+		return nil, fmt.Errorf("%w: token is not meant for %s", ErrInvalidToken, authenticator.Audience)
+	}
+
+	userId, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: subject should be a user id", ErrInvalidToken)
+	}
+
+	return &Identity{
+		UserID:    userId,
+		Roles:     claims.Roles,
+		Companies: claims.Companies,
+	}, nil
+}
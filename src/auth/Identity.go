@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	RoleCompanyAdmin  = "company-admin"
+	RolePlatformAdmin = "platform-admin"
+)
+
+// ScopeCreateJobOffers lets an API token create job offers of its company.
+const ScopeCreateJobOffers = "job-offers:create"
+
+// Scopes are the scopes API tokens can be given.
+var Scopes = []string{ScopeCreateJobOffers}
+
+// Identity is the authenticated caller of a request, either a user or the API
+// token of a company.
+type Identity struct {
+	UserID int
+	Roles  []string
+	// Companies are the ids of the companies the user is a member of, or the
+	// company the API token was created for.
+	Companies []int
+	// TokenID is the id of the API token the request was made with.
+	TokenID int      `json:",omitempty"`
+	Scopes  []string `json:",omitempty"`
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx carrying the identity.
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity carried by ctx, if there is one.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// HasAnyRole tells whether the identity has at least one of the roles.
+func (identity *Identity) HasAnyRole(roles ...string) bool {
+	for _, role := range roles {
+		for _, identityRole := range identity.Roles {
+			if identityRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsMemberOf tells whether the user is a member of the company.
+func (identity *Identity) IsMemberOf(companyId int) bool {
+	for _, company := range identity.Companies {
+		if company == companyId {
+			return true
+		}
+	}
+	return false
+}
+
+// IsApiToken tells whether the request was made with an API token.
+func (identity *Identity) IsApiToken() bool {
+	return identity.TokenID != 0
+}
+
+// Subject names the caller, as user:<id> or api-token:<id>.
+func (identity *Identity) Subject() string {
+	if identity.IsApiToken() {
+		return fmt.Sprintf("api-token:%d", identity.TokenID)
+	}
+	return fmt.Sprintf("user:%d", identity.UserID)
+}
+
+// HasScope tells whether the API token has the scope.
+func (identity *Identity) HasScope(scope string) bool {
+	for _, identityScope := range identity.Scopes {
+		if identityScope == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var ErrUnknownKey = errors.New("Unknown signing key")
+
+// KeySet holds the keys tokens can be signed with, by key id. When the set has
+// a single key, it verifies every token whatever key id the token names.
+type KeySet struct {
+	keys map[string]interface{}
+}
+
+// Key returns the key the token names and checks that the token is signed
+// with an algorithm of the same family, so a public key is never used as an
+// HMAC secret.
+func (keySet *KeySet) Key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := keySet.keys[kid]
+	if !ok && len(keySet.keys) == 1 {
+		for _, onlyKey := range keySet.keys {
+			key, ok = onlyKey, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		_, isRSA := token.Method.(*jwt.SigningMethodRSA)
+		_, isPSS := token.Method.(*jwt.SigningMethodRSAPSS)
+		ok = isRSA || isPSS
+	case *ecdsa.PublicKey:
+		_, ok = token.Method.(*jwt.SigningMethodECDSA)
+	case []byte:
+		_, ok = token.Method.(*jwt.SigningMethodHMAC)
+	}
+	if !ok {
+		return nil, fmt.Errorf("Unexpected signing method %s", token.Method.Alg())
+	}
+
+	return key, nil
+}
+
+// NewStaticKeySet uses a single key for every token. A PEM encoded RSA or EC
+// public key is used as is, and any other value is used as an HMAC secret.
+func NewStaticKeySet(key string) (*KeySet, error) {
+	if !strings.HasPrefix(strings.TrimSpace(key), "-----BEGIN") {
+		return &KeySet{keys: map[string]interface{}{"": []byte(key)}}, nil
+	}
+
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(key)); err == nil {
+		return &KeySet{keys: map[string]interface{}{"": rsaKey}}, nil
+	}
+	ecKey, err := jwt.ParseECPublicKeyFromPEM([]byte(key))
+	if err != nil {
+		return nil, errors.New("Static key is neither an RSA nor an EC public key")
+	}
+	return &KeySet{keys: map[string]interface{}{"": ecKey}}, nil
+}
+
+// LoadJWKS reads the keys of a JSON Web Key Set file.
+func LoadJWKS(path string) (*KeySet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+// ParseJWKS parses the RSA, EC and symmetric keys of a JSON Web Key Set. Keys
+// meant only for encryption are left out.
+func ParseJWKS(data []byte) (*KeySet, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+
+	keySet := KeySet{keys: map[string]interface{}{}}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use == "enc" {
+			continue
+		}
+		key, err := jwk.key()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid key %q: %s", jwk.Kid, err.Error())
+		}
+		keySet.keys[jwk.Kid] = key
+	}
+	if len(keySet.keys) == 0 {
+		return nil, errors.New("Key set has no signing keys")
+	}
+
+	return &keySet, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+func (jwk *jsonWebKey) key() (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, ok := curves[jwk.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := decodeInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "oct":
+		return base64.RawURLEncoding.DecodeString(jwk.K)
+	}
+	return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+}
+
+func decodeInt(value string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(bytes), nil
+}
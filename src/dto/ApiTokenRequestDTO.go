@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/go-playground/validator"
+)
+
+type ApiTokenRequestDTO struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"omitempty,dive,oneof=job-offers:create"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (u *ApiTokenRequestDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(u)
+}
@@ -0,0 +1,14 @@
+package dto
+
+import (
+	"github.com/go-playground/validator"
+)
+
+type ApplicationRequestDTO struct {
+	CoverLetter string `json:"cover_letter" validate:"max=10000"`
+}
+
+func (u *ApplicationRequestDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(u)
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type ApplicationResponseDTO struct {
+	ID          int                  `json:"id"`
+	JobOfferID  int                  `json:"job_offer_id"`
+	CandidateID int                  `json:"candidate_id"`
+	CoverLetter string               `json:"cover_letter"`
+	Stage       string               `json:"stage"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	JobOffer    *JobOfferResponseDTO `json:"job_offer,omitempty"`
+	// JobOfferRemoved tells candidates the offer was purged from the trash
+	// after they applied.
+	JobOfferRemoved bool `json:"job_offer_removed,omitempty"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+type ApplicationStageChangeResponseDTO struct {
+	FromStage string    `json:"from_stage,omitempty"`
+	ToStage   string    `json:"to_stage"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
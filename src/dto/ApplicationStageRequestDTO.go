@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/go-playground/validator"
+)
+
+type ApplicationStageRequestDTO struct {
+	Stage string `json:"stage" validate:"required"`
+	Note  string `json:"note" validate:"max=2000"`
+}
+
+func (u *ApplicationStageRequestDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(u)
+}
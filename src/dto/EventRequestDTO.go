@@ -1,6 +0,0 @@
-package dto
-
-type EventRequestDTO struct {
-	Timestamp string
-	Message   string
-}
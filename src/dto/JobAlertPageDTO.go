@@ -0,0 +1,8 @@
+package dto
+
+type JobAlertPageDTO struct {
+	Items      []*JobAlertResponseDTO `json:"items"`
+	NextCursor string                 `json:"next_cursor"`
+	HasMore    bool                   `json:"has_more"`
+	Total      *int                   `json:"total,omitempty"`
+}
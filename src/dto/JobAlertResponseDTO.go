@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type JobAlertResponseDTO struct {
+	ID              int                  `json:"id"`
+	SavedSearchID   int                  `json:"saved_search_id"`
+	SavedSearchName string               `json:"saved_search_name"`
+	JobOffer        *JobOfferResponseDTO `json:"job_offer"`
+	CreatedAt       time.Time            `json:"created_at"`
+	DismissedAt     *time.Time           `json:"dismissed_at,omitempty"`
+}
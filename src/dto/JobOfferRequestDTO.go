@@ -1,17 +1,64 @@
 package dto
 
-import "github.com/go-playground/validator"
+import (
+	"errors"
+	"fmt"
+	"jobs-ms/src/utils"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+)
 
 type JobOfferRequestDTO struct {
-	CompanyID                  int    `validate:"required"`
-	Position                   string `validate:"required"`
-	JobDescription             string `validate:"required"`
-	DailyActivitiesDescription string `validate:"required"`
-	Skills                     string `validate:"required"`
-	Link                       string `validate:"required"`
+	CompanyID                  int        `json:"company_id" validate:"required"`
+	Position                   string     `json:"position" validate:"required"`
+	JobDescription             string     `json:"job_description" validate:"required"`
+	DailyActivitiesDescription string     `json:"daily_activities_description" validate:"required"`
+	Skills                     []string   `json:"skills" validate:"required,min=1,dive,required"`
+	Link                       string     `json:"link" validate:"required"`
+	Status                     string     `json:"status" validate:"omitempty,oneof=draft published"`
+	ValidFrom                  *time.Time `json:"valid_from"`
+	ValidUntil                 *time.Time `json:"valid_until"`
+	Stages                     []string   `json:"stages" validate:"omitempty,min=2,max=20,unique,dive,required,max=50"`
+	SalaryMin                  *float64   `json:"salary_min" validate:"omitempty,min=0"`
+	SalaryMax                  *float64   `json:"salary_max" validate:"omitempty,min=0"`
+	Currency                   string     `json:"currency" validate:"required_with=SalaryMin SalaryMax"`
+	Period                     string     `json:"period" validate:"required_with=SalaryMin SalaryMax,omitempty,oneof=hourly monthly yearly"`
+	City                       string     `json:"city" validate:"max=100"`
+	Country                    string     `json:"country" validate:"omitempty,len=2,alpha"`
+	Latitude                   *float64   `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude                  *float64   `json:"longitude" validate:"omitempty,min=-180,max=180"`
+	RemotePolicy               string     `json:"remote_policy" validate:"omitempty,oneof=onsite hybrid remote"`
+	EmploymentType             string     `json:"employment_type" validate:"omitempty,oneof=full-time part-time contract internship"`
+	Seniority                  string     `json:"seniority" validate:"omitempty,oneof=junior mid senior lead"`
+	WorkSchedule               string     `json:"work_schedule" validate:"omitempty,oneof=fixed flexible shifts"`
 }
 
+// Validate checks the offer after upper casing its currency, the way it is
+// stored and searched by.
 func (u *JobOfferRequestDTO) Validate() error {
+	u.Currency = strings.ToUpper(strings.TrimSpace(u.Currency))
+
 	validate := validator.New()
-	return validate.Struct(u)
+	if err := validate.Struct(u); err != nil {
+		return err
+	}
+
+	if u.ValidFrom != nil && u.ValidUntil != nil && !u.ValidUntil.After(*u.ValidFrom) {
+		return errors.New("Valid until should be after valid from")
+	}
+
+	if u.Currency != "" && !utils.IsCurrencyCode(u.Currency) {
+		return fmt.Errorf("Currency %s is not an ISO 4217 currency code", u.Currency)
+	}
+	if u.SalaryMin != nil && u.SalaryMax != nil && *u.SalaryMin > *u.SalaryMax {
+		return errors.New("Salary min should not be greater than salary max")
+	}
+
+	if (u.Latitude == nil) != (u.Longitude == nil) {
+		return errors.New("Latitude and longitude should be given together")
+	}
+
+	return nil
 }
@@ -1,11 +1,34 @@
 package dto
 
+import "time"
+
 type JobOfferResponseDTO struct {
 	ID                         int
 	CompanyID                  int
 	Position                   string
 	JobDescription             string
 	DailyActivitiesDescription string
-	Skills                     string
+	Skills                     []string
 	Link                       string
+	Status                     string
+	ValidFrom                  *time.Time `json:"valid_from"`
+	ValidUntil                 *time.Time `json:"valid_until"`
+	Stages                     []string   `json:"stages"`
+	SalaryMin                  *float64   `json:"salary_min,omitempty"`
+	SalaryMax                  *float64   `json:"salary_max,omitempty"`
+	Currency                   string     `json:"currency,omitempty"`
+	Period                     string     `json:"period,omitempty"`
+	City                       string     `json:"city,omitempty"`
+	Country                    string     `json:"country,omitempty"`
+	Latitude                   *float64   `json:"latitude,omitempty"`
+	Longitude                  *float64   `json:"longitude,omitempty"`
+	RemotePolicy               string     `json:"remote_policy,omitempty"`
+	EmploymentType             string     `json:"employment_type,omitempty"`
+	Seniority                  string     `json:"seniority,omitempty"`
+	WorkSchedule               string     `json:"work_schedule,omitempty"`
+	CreatedAt                  time.Time
+	DeletedAt                  *time.Time `json:"deleted_at,omitempty"`
+	Relevance                  float64
+	// Locale is the language the text fields are in.
+	Locale string `json:"locale,omitempty"`
 }
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type JobOfferRevisionResponseDTO struct {
+	Revision       int              `json:"revision"`
+	Action         string           `json:"action"`
+	SourceRevision *int             `json:"source_revision,omitempty"`
+	ChangedBy      string           `json:"changed_by"`
+	CreatedAt      time.Time        `json:"created_at"`
+	Changes        []FieldChangeDTO `json:"changes"`
+}
+
+type FieldChangeDTO struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/go-playground/validator"
+)
+
+type JobOfferTranslationRequestDTO struct {
+	Position                   string   `json:"position" validate:"required"`
+	JobDescription             string   `json:"job_description" validate:"required"`
+	DailyActivitiesDescription string   `json:"activities_description" validate:"required"`
+	Skills                     []string `json:"skills" validate:"dive,required"`
+}
+
+func (u *JobOfferTranslationRequestDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(u)
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type JobOfferTranslationResponseDTO struct {
+	JobOfferID                 int       `json:"job_offer_id"`
+	Locale                     string    `json:"locale"`
+	Position                   string    `json:"position"`
+	JobDescription             string    `json:"job_description"`
+	DailyActivitiesDescription string    `json:"activities_description"`
+	Skills                     []string  `json:"skills"`
+	UpdatedAt                  time.Time `json:"updated_at"`
+}
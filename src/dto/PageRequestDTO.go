@@ -0,0 +1,8 @@
+package dto
+
+type PageRequestDTO struct {
+	Limit        int
+	Cursor       string
+	Sort         string
+	IncludeTotal bool
+}
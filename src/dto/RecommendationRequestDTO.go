@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/go-playground/validator"
+)
+
+// RecommendationRequestDTO is the profile of a candidate offers are
+// recommended for.
+type RecommendationRequestDTO struct {
+	Skills            []string `json:"skills" validate:"required,min=1,max=50,dive,required"`
+	Position          string   `json:"position" validate:"max=200"`
+	ExcludedCompanies []int    `json:"excluded_companies"`
+	Limit             int      `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func (u *RecommendationRequestDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(u)
+}
@@ -0,0 +1,20 @@
+package dto
+
+// RecommendationResponseDTO is a recommended offer with the score it was
+// ranked by and how the score was made up.
+type RecommendationResponseDTO struct {
+	Offer           *JobOfferResponseDTO `json:"offer"`
+	Score           float64              `json:"score"`
+	Breakdown       ScoreBreakdownDTO    `json:"breakdown"`
+	MatchedSkills   []string             `json:"matched_skills"`
+	MissingSkills   []string             `json:"missing_skills"`
+	MentionedSkills []string             `json:"mentioned_skills"`
+}
+
+// ScoreBreakdownDTO holds the parts of the score, each between 0 and 1,
+// before they are weighted.
+type ScoreBreakdownDTO struct {
+	Skills      float64 `json:"skills"`
+	Position    float64 `json:"position"`
+	Description float64 `json:"description"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type SavedOfferPageDTO struct {
+	Items      []*SavedOfferResponseDTO `json:"items"`
+	NextCursor string                   `json:"next_cursor"`
+	HasMore    bool                     `json:"has_more"`
+	Total      *int                     `json:"total,omitempty"`
+}
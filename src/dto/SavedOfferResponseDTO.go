@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// SavedOfferResponseDTO is a saved job offer. Offers that were closed, have
+// expired or were deleted since they were saved are kept in the list and
+// flagged as unavailable. Only the id is left of offers purged from the trash.
+type SavedOfferResponseDTO struct {
+	*JobOfferResponseDTO
+	SavedAt           time.Time `json:"saved_at"`
+	Available         bool      `json:"available"`
+	UnavailableReason string    `json:"unavailable_reason,omitempty"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/go-playground/validator"
+)
+
+// SavedSearchRequestDTO is a search to be alerted about. Query is the free
+// text of the search and Filters are the other parameters of
+// /jobOffers/search.
+type SavedSearchRequestDTO struct {
+	Name    string              `json:"name" validate:"required,max=100"`
+	Query   string              `json:"query" validate:"max=500"`
+	Filters map[string][]string `json:"filters"`
+}
+
+func (u *SavedSearchRequestDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(u)
+}
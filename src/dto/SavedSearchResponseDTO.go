@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type SavedSearchResponseDTO struct {
+	ID        int                 `json:"id"`
+	UserID    int                 `json:"user_id"`
+	Name      string              `json:"name"`
+	Query     string              `json:"query"`
+	Filters   map[string][]string `json:"filters"`
+	CreatedAt time.Time           `json:"created_at"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type SkillResponseDTO struct {
+	ID         int
+	Name       string
+	OfferCount int
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/go-playground/validator"
+)
+
+type WebhookRequestDTO struct {
+	CompanyID  int      `json:"company_id" validate:"required"`
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=JobOfferCreated JobOfferUpdated JobOfferPublished JobOfferClosed JobOfferExpired JobOfferDeleted JobOfferRestored JobOfferReverted ApplicationStageChanged"`
+	Active     *bool    `json:"active"`
+}
+
+func (u *WebhookRequestDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(u)
+}
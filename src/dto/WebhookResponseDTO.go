@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type WebhookResponseDTO struct {
+	ID         int       `json:"id"`
+	CompanyID  int       `json:"company_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"jobs-ms/src/model"
+	"strconv"
+	"time"
+)
+
+// ApplicationStageChanged is published whenever an application moves to
+// another stage of the pipeline of its offer.
+type ApplicationStageChanged struct {
+	ID            string    `json:"-"`
+	SchemaVersion string    `json:"schema_version"`
+	ApplicationID int       `json:"application_id"`
+	OfferID       int       `json:"offer_id"`
+	CompanyID     int       `json:"company_id"`
+	CandidateID   int       `json:"candidate_id"`
+	FromStage     string    `json:"from_stage"`
+	ToStage       string    `json:"to_stage"`
+	Note          string    `json:"note,omitempty"`
+	Time          time.Time `json:"time"`
+}
+
+// NewApplicationStageChanged sets the schema version of the event to the
+// current one and keeps its time in UTC.
+func NewApplicationStageChanged(event ApplicationStageChanged) ApplicationStageChanged {
+	event.SchemaVersion = SchemaVersion
+	event.Time = event.Time.UTC()
+	return event
+}
+
+func (event ApplicationStageChanged) EventID() string {
+	return event.ID
+}
+
+func (ApplicationStageChanged) EventType() string {
+	return model.EventApplicationStageChanged
+}
+
+func (event ApplicationStageChanged) Subject() string {
+	return strconv.Itoa(event.ApplicationID)
+}
+
+func (event ApplicationStageChanged) Company() int {
+	return event.CompanyID
+}
+
+func (event ApplicationStageChanged) OccurredAt() time.Time {
+	return event.Time
+}
+
+func (event ApplicationStageChanged) Version() string {
+	return event.SchemaVersion
+}
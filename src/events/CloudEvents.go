@@ -0,0 +1,87 @@
+package events
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const (
+	CloudEventsSpecVersion = "1.0"
+	CloudEventsContentType = "application/cloudevents+json"
+
+	// EventSource identifies this service as the source of its events.
+	EventSource = "/jobs-ms"
+	// The event type prefixes turn the event types into the reverse-DNS style
+	// names CloudEvents recommends, such as jobs.joboffer.JobOfferCreated.
+	EventTypePrefix            = "jobs.joboffer."
+	ApplicationEventTypePrefix = "jobs.application."
+	AlertEventTypePrefix       = "jobs.alert."
+)
+
+// CloudEvent is a domain event in the CloudEvents 1.0 format. The schema
+// version of its data is carried in the schemaversion extension attribute.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	SchemaVersion   string          `json:"schemaversion"`
+	Data            json.RawMessage `json:"data"`
+}
+
+func ToCloudEvent(event DomainEvent) (*CloudEvent, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              event.EventID(),
+		Source:          EventSource,
+		Type:            cloudEventType(event),
+		Subject:         event.Subject(),
+		Time:            event.OccurredAt().UTC().Format(time.RFC3339),
+		DataContentType: "application/json",
+		SchemaVersion:   event.Version(),
+		Data:            data,
+	}, nil
+}
+
+// Structured returns the event in structured content mode, where the
+// attributes and the data are sent together as one JSON document.
+func (event *CloudEvent) Structured() ([]byte, error) {
+	return json.Marshal(event)
+}
+
+// BinaryHeaders returns the attributes of the event as the HTTP headers of
+// binary content mode, where the body carries only the data.
+func (event *CloudEvent) BinaryHeaders() http.Header {
+	header := http.Header{}
+	header.Set("ce-specversion", event.SpecVersion)
+	header.Set("ce-id", event.ID)
+	header.Set("ce-source", event.Source)
+	header.Set("ce-type", event.Type)
+	if event.Subject != "" {
+		header.Set("ce-subject", event.Subject)
+	}
+	header.Set("ce-time", event.Time)
+	header.Set("ce-schemaversion", event.SchemaVersion)
+	header.Set("content-type", event.DataContentType)
+	return header
+}
+
+func cloudEventType(event DomainEvent) string {
+	switch event.(type) {
+	case ApplicationStageChanged:
+		return ApplicationEventTypePrefix + event.EventType()
+	case JobAlertCreated:
+		return AlertEventTypePrefix + event.EventType()
+	default:
+		return EventTypePrefix + event.EventType()
+	}
+}
@@ -0,0 +1,101 @@
+package events
+
+import (
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+	"strconv"
+	"time"
+)
+
+// SchemaVersion is the version of the data carried by the domain events. It
+// changes whenever the data changes in a way that is not backwards compatible.
+const SchemaVersion = "1.0"
+
+// DomainEvent is a typed event describing a change of a job offer, of an
+// application to one or an alert about one.
+type DomainEvent interface {
+	EventID() string
+	EventType() string
+	Subject() string
+	Company() int
+	OccurredAt() time.Time
+	Version() string
+}
+
+// JobOfferEvent holds the data every job offer event carries.
+type JobOfferEvent struct {
+	ID            string                   `json:"-"`
+	SchemaVersion string                   `json:"schema_version"`
+	OfferID       int                      `json:"offer_id"`
+	CompanyID     int                      `json:"company_id"`
+	Revision      int                      `json:"revision"`
+	Time          time.Time                `json:"time"`
+	Offer         *dto.JobOfferResponseDTO `json:"offer"`
+}
+
+func (event JobOfferEvent) EventID() string {
+	return event.ID
+}
+
+func (event JobOfferEvent) Subject() string {
+	return strconv.Itoa(event.OfferID)
+}
+
+func (event JobOfferEvent) Company() int {
+	return event.CompanyID
+}
+
+func (event JobOfferEvent) OccurredAt() time.Time {
+	return event.Time
+}
+
+func (event JobOfferEvent) Version() string {
+	return event.SchemaVersion
+}
+
+type JobOfferCreated struct{ JobOfferEvent }
+type JobOfferUpdated struct{ JobOfferEvent }
+type JobOfferPublished struct{ JobOfferEvent }
+type JobOfferClosed struct{ JobOfferEvent }
+type JobOfferExpired struct{ JobOfferEvent }
+type JobOfferDeleted struct{ JobOfferEvent }
+type JobOfferRestored struct{ JobOfferEvent }
+type JobOfferReverted struct{ JobOfferEvent }
+
+func (JobOfferCreated) EventType() string   { return model.EventJobOfferCreated }
+func (JobOfferUpdated) EventType() string   { return model.EventJobOfferUpdated }
+func (JobOfferPublished) EventType() string { return model.EventJobOfferPublished }
+func (JobOfferClosed) EventType() string    { return model.EventJobOfferClosed }
+func (JobOfferExpired) EventType() string   { return model.EventJobOfferExpired }
+func (JobOfferDeleted) EventType() string   { return model.EventJobOfferDeleted }
+func (JobOfferRestored) EventType() string  { return model.EventJobOfferRestored }
+func (JobOfferReverted) EventType() string  { return model.EventJobOfferReverted }
+
+// NewJobOfferEvent creates the typed event of the given type. The time of the
+// event is kept in UTC and the schema version is set to the current one.
+func NewJobOfferEvent(eventType string, event JobOfferEvent) (DomainEvent, error) {
+	event.SchemaVersion = SchemaVersion
+	event.Time = event.Time.UTC()
+
+	switch eventType {
+	case model.EventJobOfferCreated:
+		return JobOfferCreated{event}, nil
+	case model.EventJobOfferUpdated:
+		return JobOfferUpdated{event}, nil
+	case model.EventJobOfferPublished:
+		return JobOfferPublished{event}, nil
+	case model.EventJobOfferClosed:
+		return JobOfferClosed{event}, nil
+	case model.EventJobOfferExpired:
+		return JobOfferExpired{event}, nil
+	case model.EventJobOfferDeleted:
+		return JobOfferDeleted{event}, nil
+	case model.EventJobOfferRestored:
+		return JobOfferRestored{event}, nil
+	case model.EventJobOfferReverted:
+		return JobOfferReverted{event}, nil
+	default:
+		return nil, fmt.Errorf("Unknown event type %s", eventType)
+	}
+}
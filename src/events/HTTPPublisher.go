@@ -0,0 +1,72 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	ModeStructured = "structured"
+	ModeBinary     = "binary"
+)
+
+// HTTPPublisher posts events to the events-ms endpoint as CloudEvents, in
+// structured or binary content mode.
+type HTTPPublisher struct {
+	Endpoint string
+	Client   *http.Client
+	Mode     string
+}
+
+func NewHTTPPublisher(endpoint string, client *http.Client, mode string) (*HTTPPublisher, error) {
+	if mode == "" {
+		mode = ModeStructured
+	}
+	if mode != ModeStructured && mode != ModeBinary {
+		return nil, fmt.Errorf("Unknown CloudEvents content mode %s", mode)
+	}
+
+	return &HTTPPublisher{
+		endpoint,
+		client,
+		mode,
+	}, nil
+}
+
+func (publisher *HTTPPublisher) Publish(ctx context.Context, event DomainEvent) error {
+	cloudEvent, err := ToCloudEvent(event)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	header := http.Header{}
+	if publisher.Mode == ModeBinary {
+		body = cloudEvent.Data
+		header = cloudEvent.BinaryHeaders()
+	} else {
+		if body, err = cloudEvent.Structured(); err != nil {
+			return err
+		}
+		header.Set("content-type", CloudEventsContentType)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", publisher.Endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+
+	resp, err := publisher.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("events-ms responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"jobs-ms/src/dto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type HTTPPublisherUnitTestsSuite struct {
+	suite.Suite
+	eventsServer *httptest.Server
+	header       http.Header
+	body         []byte
+	event        DomainEvent
+}
+
+func TestHTTPPublisherUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(HTTPPublisherUnitTestsSuite))
+}
+
+func (suite *HTTPPublisherUnitTestsSuite) SetupTest() {
+	suite.eventsServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.header = r.Header
+		suite.body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	suite.event, _ = NewJobOfferEvent("JobOfferDeleted", JobOfferEvent{
+		ID:        "12",
+		OfferID:   5,
+		CompanyID: 2,
+		Revision:  4,
+		Time:      time.Date(2022, 6, 1, 14, 30, 0, 0, time.FixedZone("CEST", 2*60*60)),
+		Offer:     &dto.JobOfferResponseDTO{ID: 5, CompanyID: 2, Position: "pos"},
+	})
+}
+
+func (suite *HTTPPublisherUnitTestsSuite) TearDownTest() {
+	suite.eventsServer.Close()
+}
+
+func (suite *HTTPPublisherUnitTestsSuite) TestHTTPPublisher_Publish_StructuredMode() {
+	publisher, _ := NewHTTPPublisher(suite.eventsServer.URL, suite.eventsServer.Client(), ModeStructured)
+
+	err := publisher.Publish(context.Background(), suite.event)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), CloudEventsContentType, suite.header.Get("content-type"))
+	var received CloudEvent
+	json.Unmarshal(suite.body, &received)
+	assert.Equal(suite.T(), "1.0", received.SpecVersion)
+	assert.Equal(suite.T(), "12", received.ID)
+	assert.Equal(suite.T(), EventSource, received.Source)
+	assert.Equal(suite.T(), "jobs.joboffer.JobOfferDeleted", received.Type)
+	assert.Equal(suite.T(), "5", received.Subject)
+	assert.Equal(suite.T(), "2022-06-01T12:30:00Z", received.Time)
+	assert.Equal(suite.T(), SchemaVersion, received.SchemaVersion)
+
+	var data JobOfferEvent
+	json.Unmarshal(received.Data, &data)
+	assert.Equal(suite.T(), 2, data.CompanyID)
+	assert.Equal(suite.T(), 4, data.Revision)
+	assert.Equal(suite.T(), "pos", data.Offer.Position)
+}
+
+func (suite *HTTPPublisherUnitTestsSuite) TestHTTPPublisher_Publish_BinaryMode() {
+	publisher, _ := NewHTTPPublisher(suite.eventsServer.URL, suite.eventsServer.Client(), ModeBinary)
+
+	err := publisher.Publish(context.Background(), suite.event)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "application/json", suite.header.Get("content-type"))
+	assert.Equal(suite.T(), "1.0", suite.header.Get("ce-specversion"))
+	assert.Equal(suite.T(), "12", suite.header.Get("ce-id"))
+	assert.Equal(suite.T(), EventSource, suite.header.Get("ce-source"))
+	assert.Equal(suite.T(), "jobs.joboffer.JobOfferDeleted", suite.header.Get("ce-type"))
+	assert.Equal(suite.T(), "5", suite.header.Get("ce-subject"))
+	assert.Equal(suite.T(), "2022-06-01T12:30:00Z", suite.header.Get("ce-time"))
+	assert.Equal(suite.T(), SchemaVersion, suite.header.Get("ce-schemaversion"))
+
+	var data JobOfferEvent
+	json.Unmarshal(suite.body, &data)
+	assert.Equal(suite.T(), 5, data.OfferID)
+	assert.Equal(suite.T(), time.Date(2022, 6, 1, 12, 30, 0, 0, time.UTC), data.Time)
+}
+
+func (suite *HTTPPublisherUnitTestsSuite) TestNewHTTPPublisher_UnknownMode() {
+	publisher, err := NewHTTPPublisher(suite.eventsServer.URL, suite.eventsServer.Client(), "batched")
+
+	assert.Nil(suite.T(), publisher)
+	assert.NotNil(suite.T(), err)
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"jobs-ms/src/model"
+	"strconv"
+	"time"
+)
+
+// JobAlertCreated is published when an offer matches a saved search of a
+// user, so the user can be notified.
+type JobAlertCreated struct {
+	ID            string    `json:"-"`
+	SchemaVersion string    `json:"schema_version"`
+	AlertID       int       `json:"alert_id"`
+	UserID        int       `json:"user_id"`
+	SavedSearchID int       `json:"saved_search_id"`
+	OfferID       int       `json:"offer_id"`
+	CompanyID     int       `json:"company_id"`
+	Time          time.Time `json:"time"`
+}
+
+// NewJobAlertCreated sets the schema version of the event to the current one
+// and keeps its time in UTC.
+func NewJobAlertCreated(event JobAlertCreated) JobAlertCreated {
+	event.SchemaVersion = SchemaVersion
+	event.Time = event.Time.UTC()
+	return event
+}
+
+func (event JobAlertCreated) EventID() string {
+	return event.ID
+}
+
+func (JobAlertCreated) EventType() string {
+	return model.EventJobAlertCreated
+}
+
+func (event JobAlertCreated) Subject() string {
+	return strconv.Itoa(event.AlertID)
+}
+
+func (event JobAlertCreated) Company() int {
+	return event.CompanyID
+}
+
+func (event JobAlertCreated) OccurredAt() time.Time {
+	return event.Time
+}
+
+func (event JobAlertCreated) Version() string {
+	return event.SchemaVersion
+}
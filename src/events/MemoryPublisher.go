@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher records the published events in memory, so tests can
+// assert on them.
+type MemoryPublisher struct {
+	mutex  sync.Mutex
+	events []DomainEvent
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (publisher *MemoryPublisher) Publish(ctx context.Context, event DomainEvent) error {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	publisher.events = append(publisher.events, event)
+	return nil
+}
+
+// Events returns the events published so far, oldest first.
+func (publisher *MemoryPublisher) Events() []DomainEvent {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	return append([]DomainEvent{}, publisher.events...)
+}
+
+func (publisher *MemoryPublisher) Reset() {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	publisher.events = nil
+}
@@ -0,0 +1,155 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSPublisher publishes events as structured CloudEvents to a NATS
+// compatible message broker on the subject "<Subject>.<event type>". Every
+// publish is confirmed with a PING, so an event counts as delivered only once
+// the broker has processed it.
+type NATSPublisher struct {
+	Address string
+	Subject string
+	Timeout time.Duration
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewNATSPublisher creates a publisher for a broker URL such as
+// nats://localhost:4222. The connection is opened on the first publish.
+func NewNATSPublisher(brokerURL string, subject string, timeout time.Duration) (*NATSPublisher, error) {
+	address := brokerURL
+	if strings.Contains(brokerURL, "://") {
+		parsed, err := url.Parse(brokerURL)
+		if err != nil {
+			return nil, err
+		}
+		address = parsed.Host
+	}
+	if address == "" {
+		return nil, errors.New("Message broker address is missing")
+	}
+
+	return &NATSPublisher{Address: address, Subject: subject, Timeout: timeout}, nil
+}
+
+func (publisher *NATSPublisher) Publish(ctx context.Context, event DomainEvent) error {
+	cloudEvent, err := ToCloudEvent(event)
+	if err != nil {
+		return err
+	}
+
+	data, err := cloudEvent.Structured()
+	if err != nil {
+		return err
+	}
+
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	if err := publisher.publish(ctx, fmt.Sprintf("%s.%s", publisher.Subject, event.EventType()), data); err != nil {
+		publisher.close()
+		return err
+	}
+	return nil
+}
+
+func (publisher *NATSPublisher) Close() error {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	return publisher.close()
+}
+
+func (publisher *NATSPublisher) publish(ctx context.Context, subject string, data []byte) error {
+	if publisher.conn == nil {
+		if err := publisher.connect(); err != nil {
+			return err
+		}
+	}
+
+	deadline := time.Now().Add(publisher.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := publisher.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(data), data)
+	if _, err := publisher.conn.Write([]byte(message)); err != nil {
+		return err
+	}
+
+	for {
+		line, err := publisher.readLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := publisher.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("Message broker rejected event: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (publisher *NATSPublisher) connect() error {
+	conn, err := net.DialTimeout("tcp", publisher.Address, publisher.Timeout)
+	if err != nil {
+		return err
+	}
+	publisher.conn = conn
+	publisher.reader = bufio.NewReader(conn)
+
+	if err := conn.SetDeadline(time.Now().Add(publisher.Timeout)); err != nil {
+		return err
+	}
+
+	info, err := publisher.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(info, "INFO") {
+		return fmt.Errorf("Unexpected greeting from message broker: %s", info)
+	}
+
+	_, err = conn.Write([]byte("CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"jobs-ms\"}\r\n"))
+	return err
+}
+
+func (publisher *NATSPublisher) readLine() (string, error) {
+	line, err := publisher.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (publisher *NATSPublisher) close() error {
+	if publisher.conn == nil {
+		return nil
+	}
+
+	err := publisher.conn.Close()
+	publisher.conn = nil
+	publisher.reader = nil
+	return err
+}
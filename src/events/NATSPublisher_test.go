@@ -0,0 +1,132 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type NATSPublisherUnitTestsSuite struct {
+	suite.Suite
+	listener  net.Listener
+	published chan string
+	publisher *NATSPublisher
+}
+
+func TestNATSPublisherUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(NATSPublisherUnitTestsSuite))
+}
+
+// startBroker starts a minimal broker that records the published messages
+// and answers every PING with the given reply.
+func (suite *NATSPublisherUnitTestsSuite) startBroker(reply string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+	suite.listener = listener
+	suite.published = make(chan string, 10)
+
+	go serve(listener, suite.published, reply)
+
+	suite.publisher, err = NewNATSPublisher("nats://"+listener.Addr().String(), "jobs.events", time.Second)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+func (suite *NATSPublisherUnitTestsSuite) TearDownTest() {
+	suite.publisher.Close()
+	suite.listener.Close()
+}
+
+func serve(listener net.Listener, published chan<- string, reply string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer conn.Close()
+			conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+
+			reader := bufio.NewReader(conn)
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				line = strings.TrimRight(line, "\r\n")
+
+				switch {
+				case strings.HasPrefix(line, "PUB "):
+					parts := strings.Split(line, " ")
+					size, _ := strconv.Atoi(parts[2])
+					payload := make([]byte, size+2)
+					if _, err := reader.Read(payload); err != nil {
+						return
+					}
+					published <- parts[1] + " " + string(payload[:size])
+				case line == "PING":
+					conn.Write([]byte(reply + "\r\n"))
+				}
+			}
+		}()
+	}
+}
+
+func (suite *NATSPublisherUnitTestsSuite) TestNATSPublisher_Publish_SendsEventToTypedSubject() {
+	suite.startBroker("PONG")
+	event := JobOfferCreated{JobOfferEvent{ID: "1", OfferID: 3, CompanyID: 7, Time: time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)}}
+
+	err := suite.publisher.Publish(context.Background(), event)
+
+	assert.Nil(suite.T(), err)
+	message := <-suite.published
+	subject, payload, _ := strings.Cut(message, " ")
+	assert.Equal(suite.T(), "jobs.events.JobOfferCreated", subject)
+	var received CloudEvent
+	json.Unmarshal([]byte(payload), &received)
+	assert.Equal(suite.T(), "jobs.joboffer.JobOfferCreated", received.Type)
+	assert.Equal(suite.T(), "1", received.ID)
+}
+
+func (suite *NATSPublisherUnitTestsSuite) TestNATSPublisher_Publish_ReusesConnection() {
+	suite.startBroker("PONG")
+	suite.publisher.Publish(context.Background(), JobOfferCreated{JobOfferEvent{ID: "1"}})
+	conn := suite.publisher.conn
+
+	err := suite.publisher.Publish(context.Background(), JobOfferUpdated{JobOfferEvent{ID: "2"}})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), conn, suite.publisher.conn)
+	assert.Equal(suite.T(), 2, len(suite.published))
+}
+
+func (suite *NATSPublisherUnitTestsSuite) TestNATSPublisher_Publish_BrokerError() {
+	suite.startBroker("-ERR 'Permissions Violation'")
+
+	err := suite.publisher.Publish(context.Background(), JobOfferCreated{JobOfferEvent{ID: "1"}})
+
+	assert.NotNil(suite.T(), err)
+	assert.Nil(suite.T(), suite.publisher.conn)
+}
+
+func (suite *NATSPublisherUnitTestsSuite) TestNATSPublisher_Publish_BrokerDown() {
+	suite.startBroker("PONG")
+	suite.listener.Close()
+	publisher, _ := NewNATSPublisher(suite.listener.Addr().String(), "jobs.events", 100*time.Millisecond)
+
+	err := publisher.Publish(context.Background(), JobOfferCreated{JobOfferEvent{ID: "1"}})
+
+	assert.NotNil(suite.T(), err)
+}
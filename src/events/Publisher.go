@@ -0,0 +1,22 @@
+package events
+
+import "context"
+
+// Publisher delivers domain events to the other services.
+type Publisher interface {
+	Publish(context.Context, DomainEvent) error
+}
+
+// MultiPublisher publishes every event to all of its publishers. It tries
+// each of them even when an earlier one fails and returns the first error.
+type MultiPublisher []Publisher
+
+func (publishers MultiPublisher) Publish(ctx context.Context, event DomainEvent) error {
+	var firstErr error
+	for _, publisher := range publishers {
+		if err := publisher.Publish(ctx, event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
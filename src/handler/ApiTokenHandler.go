@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+type ApiTokenHandler struct {
+	Service *service.ApiTokenService
+	Logger  *logrus.Entry
+}
+
+func (handler *ApiTokenHandler) AddApiToken(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /companies/:companyId/apiTokens")
+	defer span.Finish()
+
+	companyId, idErr := getId(ctx.Param("companyId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	var tokenDTO dto.ApiTokenRequestDTO
+	if err := ctx.ShouldBindJSON(&tokenDTO); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Adding new API token for company %d", companyId))
+
+	token, err := handler.Service.Add(ctx.Request.Context(), companyId, &tokenDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, token)
+}
+
+func (handler *ApiTokenHandler) GetApiTokens(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /companies/:companyId/apiTokens")
+	defer span.Finish()
+
+	companyId, idErr := getId(ctx.Param("companyId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting API tokens for company %d", companyId))
+
+	tokens, err := handler.Service.GetCompanysApiTokens(ctx.Request.Context(), companyId)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+func (handler *ApiTokenHandler) RevokeApiToken(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "DELETE /companies/:companyId/apiTokens/:id")
+	defer span.Finish()
+
+	companyId, idErr := getId(ctx.Param("companyId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Revoking API token with id %d of company %d", id, companyId))
+
+	if err := handler.Service.Revoke(ctx.Request.Context(), companyId, id); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
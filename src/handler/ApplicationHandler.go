@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+type ApplicationHandler struct {
+	Service *service.ApplicationService
+	Logger  *logrus.Entry
+}
+
+func (handler *ApplicationHandler) Apply(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /jobOffers/:id/applications")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	var applicationDTO dto.ApplicationRequestDTO
+	if err := ctx.ShouldBindJSON(&applicationDTO); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Adding application to job offer with id %d", id))
+
+	application, err := handler.Service.Apply(ctx.Request.Context(), id, &applicationDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, application)
+}
+
+func (handler *ApplicationHandler) GetJobOffersApplications(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /jobOffers/:id/applications")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting applications to job offer with id %d", id))
+
+	applications, err := handler.Service.GetJobOffersApplications(ctx.Request.Context(), id)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, applications)
+}
+
+func (handler *ApplicationHandler) GetCandidatesApplications(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /users/:userId/applications")
+	defer span.Finish()
+
+	candidateId, idErr := getId(ctx.Param("userId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting applications of candidate %d", candidateId))
+
+	applications, err := handler.Service.GetCandidatesApplications(candidateId)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, applications)
+}
+
+func (handler *ApplicationHandler) ChangeStage(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /applications/:id/stage")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	var stageDTO dto.ApplicationStageRequestDTO
+	if err := ctx.ShouldBindJSON(&stageDTO); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Moving application with id %d to stage %s", id, stageDTO.Stage))
+
+	application, err := handler.Service.ChangeStage(ctx.Request.Context(), id, &stageDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, application)
+}
+
+func (handler *ApplicationHandler) GetHistory(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /applications/:id/history")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting history of application with id %d", id))
+
+	history, err := handler.Service.GetHistory(ctx.Request.Context(), id)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
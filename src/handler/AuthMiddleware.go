@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"jobs-ms/src/auth"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type AuthMiddleware struct {
+	Authenticator *auth.Authenticator
+	ApiTokens     auth.ApiTokenVerifier
+	Logger        *logrus.Entry
+}
+
+// Authenticate puts the identity of the bearer token of the request, a JWT or
+// an API token, in the request context. Requests without a token stay
+// anonymous and requests with an invalid one are rejected.
+func (middleware *AuthMiddleware) Authenticate(ctx *gin.Context) {
+	header := ctx.GetHeader("Authorization")
+	if header == "" {
+		ctx.Next()
+		return
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	if token == header || token == "" {
+		middleware.Logger.Debug("Authorization header is not a bearer token")
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, "Authorization header should be a bearer token")
+		return
+	}
+
+	identity, err := middleware.authenticate(token)
+	if err != nil {
+		middleware.Logger.Debug(err.Error())
+		if !errors.Is(err, auth.ErrInvalidToken) {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), identity))
+	ctx.Next()
+}
+
+func (middleware *AuthMiddleware) authenticate(token string) (*auth.Identity, error) {
+	if !auth.IsApiToken(token) {
+		return middleware.Authenticator.Authenticate(token)
+	}
+	if middleware.ApiTokens == nil {
+		return nil, fmt.Errorf("%w: API tokens are not accepted", auth.ErrInvalidToken)
+	}
+	return middleware.ApiTokens.Verify(token)
+}
+
+// RequireRole rejects anonymous requests, requests made with API tokens and
+// requests of users that have none of the roles.
+func (middleware *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return middleware.RequireRoleOrScope("", roles...)
+}
+
+// RequireUser rejects anonymous requests, requests made with API tokens and,
+// when param is not empty, requests of users other than the one whose id is
+// the path parameter.
+func (middleware *AuthMiddleware) RequireUser(param string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		identity, ok := auth.FromContext(ctx.Request.Context())
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, "Authentication is required")
+			return
+		}
+		if identity.IsApiToken() || (param != "" && strconv.Itoa(identity.UserID) != ctx.Param(param)) {
+			middleware.Logger.Debug(fmt.Sprintf("%s may not act for user %s", identity.Subject(), ctx.Param(param)))
+			ctx.AbortWithStatusJSON(http.StatusForbidden, "Only the user can make this request")
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// RequireRoleOrScope is RequireRole that also lets through requests made with
+// API tokens that have the scope.
+func (middleware *AuthMiddleware) RequireRoleOrScope(scope string, roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		identity, ok := auth.FromContext(ctx.Request.Context())
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, "Authentication is required")
+			return
+		}
+		if identity.IsApiToken() {
+			if scope == "" || !identity.HasScope(scope) {
+				middleware.Logger.Debug(fmt.Sprintf("API token %d does not have the scope %q", identity.TokenID, scope))
+				ctx.AbortWithStatusJSON(http.StatusForbidden, "API token is not allowed to make this request")
+				return
+			}
+			ctx.Next()
+			return
+		}
+		if !identity.HasAnyRole(roles...) {
+			middleware.Logger.Debug(fmt.Sprintf("User %d has none of the roles %v", identity.UserID, roles))
+			ctx.AbortWithStatusJSON(http.StatusForbidden, fmt.Sprintf("One of the roles %s is required", strings.Join(roles, ", ")))
+			return
+		}
+		ctx.Next()
+	}
+}
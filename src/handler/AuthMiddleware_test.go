@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/utils"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// apiTokens verifies the API tokens named after their scope.
+type apiTokens struct{}
+
+func (apiTokens) Verify(token string) (*auth.Identity, error) {
+	if token != "jobs_"+auth.ScopeCreateJobOffers && token != "jobs_" {
+		return nil, auth.ErrInvalidToken
+	}
+	identity := auth.Identity{TokenID: 3, Companies: []int{7}}
+	if scope := strings.TrimPrefix(token, "jobs_"); scope != "" {
+		identity.Scopes = []string{scope}
+	}
+	return &identity, nil
+}
+
+type AuthMiddlewareUnitTestsSuite struct {
+	suite.Suite
+	key    *rsa.PrivateKey
+	router *gin.Engine
+}
+
+func TestAuthMiddlewareUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(AuthMiddlewareUnitTestsSuite))
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().Nil(err)
+	suite.key = key
+
+	jwks := fmt.Sprintf(`{"keys": [{"kty": "RSA", "kid": "test", "use": "sig", "n": "%s", "e": "%s"}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+	keys, err := auth.ParseJWKS([]byte(jwks))
+	suite.Require().Nil(err)
+
+	middleware := AuthMiddleware{
+		Authenticator: &auth.Authenticator{Keys: keys, Issuer: "users-ms", Audience: "jobs-ms"},
+		ApiTokens:     apiTokens{},
+		Logger:        utils.Logger(),
+	}
+	suite.router = gin.New()
+	suite.router.Use(middleware.Authenticate)
+	suite.router.GET("/jobOffers", func(ctx *gin.Context) {
+		_, ok := auth.FromContext(ctx.Request.Context())
+		ctx.JSON(http.StatusOK, ok)
+	})
+	suite.router.POST("/jobOffers", middleware.RequireRoleOrScope(auth.ScopeCreateJobOffers, auth.RoleCompanyAdmin, auth.RolePlatformAdmin), func(ctx *gin.Context) {
+		identity, _ := auth.FromContext(ctx.Request.Context())
+		ctx.JSON(http.StatusCreated, identity)
+	})
+	suite.router.DELETE("/jobOffers", middleware.RequireRole(auth.RoleCompanyAdmin, auth.RolePlatformAdmin), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusNoContent, nil)
+	})
+	suite.router.GET("/users/:userId/savedOffers", middleware.RequireUser("userId"), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, nil)
+	})
+	suite.router.POST("/jobOffers/:id/applications", middleware.RequireUser(""), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusCreated, nil)
+	})
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) claims(roles ...string) auth.Claims {
+	return auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "12",
+			Issuer:    "users-ms",
+			Audience:  jwt.ClaimStrings{"jobs-ms"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles:     roles,
+		Companies: []int{7},
+	}
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) mint(claims auth.Claims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test"
+	signed, err := token.SignedString(suite.key)
+	suite.Require().Nil(err)
+	return signed
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) request(method string, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, "/jobOffers", nil)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	suite.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_Anonymous_ReadAllowed() {
+	response := suite.request(http.MethodGet, "")
+
+	assert.Equal(suite.T(), http.StatusOK, response.Code)
+	assert.Equal(suite.T(), "false", response.Body.String())
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_Anonymous_WriteRejected() {
+	response := suite.request(http.MethodPost, "")
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, response.Code)
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_Admin_PutsIdentityInContext() {
+	for _, role := range []string{auth.RoleCompanyAdmin, auth.RolePlatformAdmin} {
+		response := suite.request(http.MethodPost, suite.mint(suite.claims("candidate", role)))
+
+		assert.Equal(suite.T(), http.StatusCreated, response.Code)
+		assert.JSONEq(suite.T(), fmt.Sprintf(`{"UserID": 12, "Roles": ["candidate", "%s"], "Companies": [7]}`, role), response.Body.String())
+	}
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_MissingRole_Forbidden() {
+	response := suite.request(http.MethodPost, suite.mint(suite.claims("candidate")))
+
+	assert.Equal(suite.T(), http.StatusForbidden, response.Code)
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_InvalidTokens_Unauthorized() {
+	expired := suite.claims(auth.RoleCompanyAdmin)
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	withoutExpiry := suite.claims(auth.RoleCompanyAdmin)
+	withoutExpiry.ExpiresAt = nil
+	otherIssuer := suite.claims(auth.RoleCompanyAdmin)
+	otherIssuer.Issuer = "elsewhere"
+	otherAudience := suite.claims(auth.RoleCompanyAdmin)
+	otherAudience.Audience = jwt.ClaimStrings{"billing-ms"}
+	namedSubject := suite.claims(auth.RoleCompanyAdmin)
+	namedSubject.Subject = "admin"
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().Nil(err)
+	signedByOtherKey, err := jwt.NewWithClaims(jwt.SigningMethodRS256, suite.claims(auth.RoleCompanyAdmin)).SignedString(otherKey)
+	suite.Require().Nil(err)
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&suite.key.PublicKey)
+	suite.Require().Nil(err)
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})
+	signedWithPublicKeyAsSecret, err := jwt.NewWithClaims(jwt.SigningMethodHS256, suite.claims(auth.RoleCompanyAdmin)).SignedString(publicKeyPEM)
+	suite.Require().Nil(err)
+
+	tokens := []string{
+		"not-a-token",
+		suite.mint(expired),
+		suite.mint(withoutExpiry),
+		suite.mint(otherIssuer),
+		suite.mint(otherAudience),
+		suite.mint(namedSubject),
+		signedByOtherKey,
+		signedWithPublicKeyAsSecret,
+	}
+
+	for _, token := range tokens {
+		assert.Equal(suite.T(), http.StatusUnauthorized, suite.request(http.MethodGet, token).Code)
+		assert.Equal(suite.T(), http.StatusUnauthorized, suite.request(http.MethodPost, token).Code)
+	}
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_StaticSecret() {
+	keys, err := auth.NewStaticKeySet("local-secret")
+	suite.Require().Nil(err)
+	authenticator := auth.Authenticator{Keys: keys}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, suite.claims(auth.RolePlatformAdmin)).SignedString([]byte("local-secret"))
+	suite.Require().Nil(err)
+	identity, err := authenticator.Authenticate(token)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 12, identity.UserID)
+	assert.True(suite.T(), identity.HasAnyRole(auth.RolePlatformAdmin))
+	assert.True(suite.T(), identity.IsMemberOf(7))
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_ApiToken_CreatesOffers() {
+	response := suite.request(http.MethodPost, "jobs_"+auth.ScopeCreateJobOffers)
+
+	assert.Equal(suite.T(), http.StatusCreated, response.Code)
+	assert.JSONEq(suite.T(), `{"UserID": 0, "Roles": null, "Companies": [7], "TokenID": 3, "Scopes": ["job-offers:create"]}`, response.Body.String())
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_ApiToken_LimitedToScope() {
+	assert.Equal(suite.T(), http.StatusForbidden, suite.request(http.MethodPost, "jobs_").Code)
+	assert.Equal(suite.T(), http.StatusForbidden, suite.request(http.MethodDelete, "jobs_"+auth.ScopeCreateJobOffers).Code)
+	assert.Equal(suite.T(), http.StatusUnauthorized, suite.request(http.MethodPost, "jobs_revoked").Code)
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_RequireUser_OnlyTheUser() {
+	tests := []struct {
+		path   string
+		token  string
+		status int
+	}{
+		{"/users/12/savedOffers", suite.mint(suite.claims("candidate")), http.StatusOK},
+		{"/users/13/savedOffers", suite.mint(suite.claims("candidate")), http.StatusForbidden},
+		{"/users/13/savedOffers", suite.mint(suite.claims(auth.RolePlatformAdmin)), http.StatusForbidden},
+		{"/users/0/savedOffers", "jobs_" + auth.ScopeCreateJobOffers, http.StatusForbidden},
+		{"/users/12/savedOffers", "", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, test.path, nil)
+		if test.token != "" {
+			request.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		response := httptest.NewRecorder()
+		suite.router.ServeHTTP(response, request)
+
+		assert.Equal(suite.T(), test.status, response.Code, test.path)
+	}
+}
+
+func (suite *AuthMiddlewareUnitTestsSuite) TestAuthMiddleware_RequireUser_AnyUser() {
+	tests := []struct {
+		token  string
+		status int
+	}{
+		{suite.mint(suite.claims("candidate")), http.StatusCreated},
+		{"jobs_" + auth.ScopeCreateJobOffers, http.StatusForbidden},
+		{"", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodPost, "/jobOffers/3/applications", nil)
+		if test.token != "" {
+			request.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		response := httptest.NewRecorder()
+		suite.router.ServeHTTP(response, request)
+
+		assert.Equal(suite.T(), test.status, response.Code)
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"jobs-ms/src/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+type JobAlertHandler struct {
+	Service *service.JobAlertService
+	Logger  *logrus.Entry
+}
+
+func (handler *JobAlertHandler) GetAlerts(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /users/:userId/alerts")
+	defer span.Finish()
+
+	userId, idErr := getId(ctx.Param("userId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+	page, pageErr := getPageRequest(ctx)
+	if pageErr != nil {
+		handler.Logger.Debug(pageErr.Error())
+		ctx.JSON(http.StatusBadRequest, pageErr.Error())
+		return
+	}
+
+	includeDismissed := false
+	if value := ctx.Query("include_dismissed"); value != "" {
+		var err error
+		if includeDismissed, err = strconv.ParseBool(value); err != nil {
+			err = errors.New("Include dismissed should be a boolean")
+			handler.Logger.Debug(err.Error())
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting job alerts of user %d", userId))
+
+	alerts, err := handler.Service.GetUsersAlerts(userId, includeDismissed, page)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, alerts)
+}
+
+func (handler *JobAlertHandler) DismissAlert(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /users/:userId/alerts/:id/dismiss")
+	defer span.Finish()
+
+	userId, idErr := getId(ctx.Param("userId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Dismissing job alert with id %d of user %d", id, userId))
+
+	if err := handler.Service.Dismiss(userId, id); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
@@ -1,16 +1,15 @@
 package handler
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"jobs-ms/src/dto"
+	"jobs-ms/src/repository"
 	"jobs-ms/src/service"
+	"jobs-ms/src/utils"
 	"net/http"
-	"os"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/opentracing/opentracing-go"
@@ -35,17 +34,120 @@ func (handler *JobOfferHandler) AddJobOffer(ctx *gin.Context) {
 
 	handler.Logger.Info(fmt.Sprintf("Adding new job offer for company %d", jobOfferDTO.CompanyID))
 
-	dto, err := handler.Service.Add(&jobOfferDTO)
+	dto, err := handler.Service.Add(ctx.Request.Context(), &jobOfferDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
 
-	handler.AddSystemEvent(time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf("New job offer created with id %d", dto.ID))
+	ctx.JSON(http.StatusCreated, dto)
+}
 
-	if err != nil {
+func (handler *JobOfferHandler) UpdateJobOffer(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "PUT /jobOffers/:id")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	var jobOfferDTO dto.JobOfferRequestDTO
+	if err := ctx.ShouldBindJSON(&jobOfferDTO); err != nil {
 		handler.Logger.Debug(err.Error())
 		ctx.JSON(http.StatusBadRequest, err)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, dto)
+	handler.Logger.Info(fmt.Sprintf("Updating job offer with id %d", id))
+
+	offerDTO, err := handler.Service.Update(ctx.Request.Context(), id, &jobOfferDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offerDTO)
+}
+
+func (handler *JobOfferHandler) PatchJobOffer(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "PATCH /jobOffers/:id")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	patch, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Patching job offer with id %d", id))
+
+	offerDTO, err := handler.Service.Patch(ctx.Request.Context(), id, patch)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offerDTO)
+}
+
+func (handler *JobOfferHandler) PublishJobOffer(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /jobOffers/:id/publish")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Publishing job offer with id %d", id))
+
+	offerDTO, err := handler.Service.Publish(ctx.Request.Context(), id)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offerDTO)
+}
+
+func (handler *JobOfferHandler) CloseJobOffer(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /jobOffers/:id/close")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Closing job offer with id %d", id))
+
+	offerDTO, err := handler.Service.Close(ctx.Request.Context(), id)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offerDTO)
 }
 
 func (handler *JobOfferHandler) GetJobOffersByCompany(ctx *gin.Context) {
@@ -58,14 +160,27 @@ func (handler *JobOfferHandler) GetJobOffersByCompany(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, idErr.Error())
 		return
 	}
+	page, pageErr := getPageRequest(ctx)
+	if pageErr != nil {
+		handler.Logger.Debug(pageErr.Error())
+		ctx.JSON(http.StatusBadRequest, pageErr.Error())
+		return
+	}
+
 	handler.Logger.Info(fmt.Sprintf("Getting job offers for company %d", id))
-	offersDTO, err := handler.Service.GetCompanysOffers(id)
+
+	locale := getLocale(ctx)
+	filters := getSearchFilters(ctx)
+	filters["lang"] = []string{locale}
+
+	offersDTO, err := handler.Service.GetCompanysOffers(id, filters, page)
 	if err != nil {
 		handler.Logger.Debug(err.Error())
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
 		return
 	}
 
+	setContentLanguage(ctx, locale, offersDTO.Items...)
 	ctx.JSON(http.StatusOK, offersDTO)
 }
 
@@ -73,15 +188,27 @@ func (handler *JobOfferHandler) GetAll(ctx *gin.Context) {
 	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /jobOffers")
 	defer span.Finish()
 
+	page, pageErr := getPageRequest(ctx)
+	if pageErr != nil {
+		handler.Logger.Debug(pageErr.Error())
+		ctx.JSON(http.StatusBadRequest, pageErr.Error())
+		return
+	}
+
 	handler.Logger.Info("Getting job offers")
 
-	offersDTO, err := handler.Service.GetAll()
+	locale := getLocale(ctx)
+	filters := getSearchFilters(ctx)
+	filters["lang"] = []string{locale}
+
+	offersDTO, err := handler.Service.GetAll(filters, page)
 	if err != nil {
 		handler.Logger.Debug(err.Error())
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
 		return
 	}
 
+	setContentLanguage(ctx, locale, offersDTO.Items...)
 	ctx.JSON(http.StatusOK, offersDTO)
 }
 
@@ -89,19 +216,53 @@ func (handler *JobOfferHandler) Search(ctx *gin.Context) {
 	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /jobOffers/search")
 	defer span.Finish()
 
+	page, pageErr := getPageRequest(ctx)
+	if pageErr != nil {
+		handler.Logger.Debug(pageErr.Error())
+		ctx.JSON(http.StatusBadRequest, pageErr.Error())
+		return
+	}
+
 	handler.Logger.Info("Searching job offers")
 
-	param := ctx.Query("param")
-	offersDTO, err := handler.Service.Search(param)
+	locale := getLocale(ctx)
+	filters := getSearchFilters(ctx)
+	filters["lang"] = []string{locale}
+
+	offersDTO, err := handler.Service.Search(filters, page)
 	if err != nil {
 		handler.Logger.Debug(err.Error())
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
 		return
 	}
 
+	setContentLanguage(ctx, locale, offersDTO.Items...)
 	ctx.JSON(http.StatusOK, offersDTO)
 }
 
+func (handler *JobOfferHandler) Recommend(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /jobOffers/recommendations")
+	defer span.Finish()
+
+	var recommendationDTO dto.RecommendationRequestDTO
+	if err := ctx.ShouldBindJSON(&recommendationDTO); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	handler.Logger.Info("Recommending job offers")
+
+	recommendations, err := handler.Service.Recommend(&recommendationDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, recommendations)
+}
+
 func (handler *JobOfferHandler) GetJobOffer(ctx *gin.Context) {
 	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /jobOffers/:id")
 	defer span.Finish()
@@ -115,16 +276,49 @@ func (handler *JobOfferHandler) GetJobOffer(ctx *gin.Context) {
 
 	handler.Logger.Info(fmt.Sprintf("Getting job offer with id %d", id))
 
-	offersDTO, err := handler.Service.GetById(id)
+	locale := getLocale(ctx)
+	offersDTO, err := handler.Service.GetById(ctx.Request.Context(), id, locale)
 	if err != nil {
 		handler.Logger.Debug(err.Error())
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
 		return
 	}
 
+	setContentLanguage(ctx, locale, offersDTO)
 	ctx.JSON(http.StatusOK, offersDTO)
 }
 
+func (handler *JobOfferHandler) SaveTranslation(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "PUT /jobOffers/:id/translations/:locale")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	var translationDTO dto.JobOfferTranslationRequestDTO
+	if err := ctx.ShouldBindJSON(&translationDTO); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	locale := ctx.Param("locale")
+	handler.Logger.Info(fmt.Sprintf("Saving %s translation of job offer with id %d", locale, id))
+
+	translation, err := handler.Service.SaveTranslation(ctx.Request.Context(), id, locale, &translationDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, translation)
+}
+
 func (handler *JobOfferHandler) DeleteJobOffer(ctx *gin.Context) {
 	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "DELETE /jobOffers/:id")
 	defer span.Finish()
@@ -138,18 +332,115 @@ func (handler *JobOfferHandler) DeleteJobOffer(ctx *gin.Context) {
 
 	handler.Logger.Info(fmt.Sprintf("Deleting job offer with id %d", id))
 
-	err := handler.Service.Delete(id)
+	err := handler.Service.Delete(ctx.Request.Context(), id)
 	if err != nil {
 		handler.Logger.Debug(err.Error())
-		ctx.JSON(http.StatusBadRequest, err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
 		return
 	}
 
-	handler.AddSystemEvent(time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf("Job offer deleted with id %d", id))
-
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
+func (handler *JobOfferHandler) GetTrash(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /jobOffers/trash")
+	defer span.Finish()
+
+	companyId, idErr := getId(ctx.Query("companyId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting deleted job offers for company %d", companyId))
+
+	offersDTO, err := handler.Service.GetTrash(ctx.Request.Context(), companyId)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offersDTO)
+}
+
+func (handler *JobOfferHandler) RestoreJobOffer(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /jobOffers/:id/restore")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Restoring job offer with id %d", id))
+
+	offerDTO, err := handler.Service.Restore(ctx.Request.Context(), id)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offerDTO)
+}
+
+func (handler *JobOfferHandler) GetRevisions(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /jobOffers/:id/revisions")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting revisions of job offer with id %d", id))
+
+	revisionsDTO, err := handler.Service.GetRevisions(ctx.Request.Context(), id)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, revisionsDTO)
+}
+
+func (handler *JobOfferHandler) RevertJobOffer(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /jobOffers/:id/revisions/:rev/revert")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	revision, err := strconv.Atoi(ctx.Param("rev"))
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, "Revision should be a number")
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Reverting job offer with id %d to revision %d", id, revision))
+
+	offerDTO, err := handler.Service.RevertToRevision(ctx.Request.Context(), id, revision)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offerDTO)
+}
+
 func getId(idParam string) (int, error) {
 	id, err := strconv.ParseInt(idParam, 10, 32)
 	if err != nil {
@@ -158,23 +449,89 @@ func getId(idParam string) (int, error) {
 	return int(id), nil
 }
 
-func (handler *JobOfferHandler) AddSystemEvent(time string, message string) error {
-	event := dto.EventRequestDTO{
-		Timestamp: time,
-		Message:   message,
+func getErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, repository.ErrJobOfferNotFound),
+		errors.Is(err, repository.ErrJobOfferRevisionNotFound),
+		errors.Is(err, repository.ErrWebhookNotFound),
+		errors.Is(err, repository.ErrApiTokenNotFound),
+		errors.Is(err, repository.ErrApplicationNotFound),
+		errors.Is(err, repository.ErrSavedOfferNotFound),
+		errors.Is(err, repository.ErrSavedSearchNotFound),
+		errors.Is(err, repository.ErrJobAlertNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidPageRequest),
+		errors.Is(err, service.ErrInvalidSearchFilter),
+		errors.Is(err, service.ErrUnknownApplicationStage),
+		errors.Is(err, service.ErrInvalidLocale),
+		errors.Is(err, service.ErrInvalidWebhookURL):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidStatusTransition),
+		errors.Is(err, service.ErrOfferNotAcceptingApplications),
+		errors.Is(err, repository.ErrDuplicateApplication),
+		errors.Is(err, service.ErrInvalidStageTransition),
+		errors.Is(err, repository.ErrApplicationStageConflict):
+		return http.StatusConflict
 	}
+	return fallback
+}
 
-	b, _ := json.Marshal(&event)
-	endpoint := os.Getenv("EVENTS_MS")
-	handler.Logger.Info("Sending system event to events-ms")
-	req, _ := http.NewRequest("POST", endpoint, bytes.NewBuffer(b))
-	req.Header.Set("content-type", "application/json")
+var pageParams = map[string]bool{"limit": true, "cursor": true, "sort": true, "include_total": true}
 
-	_, err := http.DefaultClient.Do(req)
-	if err != nil {
-		handler.Logger.Debug("Error happened during sending system event")
-		return err
+func getSearchFilters(ctx *gin.Context) map[string][]string {
+	filters := map[string][]string{}
+	for name, values := range ctx.Request.URL.Query() {
+		if !pageParams[name] {
+			filters[name] = values
+		}
+	}
+	return filters
+}
+
+// getLocale negotiates the locale to respond in from the lang query parameter
+// and the Accept-Language header.
+func getLocale(ctx *gin.Context) string {
+	return utils.NegotiateLocale(ctx.Query("lang"), ctx.GetHeader("Accept-Language"))
+}
+
+// setContentLanguage sets the Content-Language header to the negotiated locale
+// when every offer is in it. Offers that are not translated to the locale are
+// in the default one, so the header is left out when the offers are mixed.
+func setContentLanguage(ctx *gin.Context, locale string, offers ...*dto.JobOfferResponseDTO) {
+	if len(offers) == 0 {
+		return
+	}
+	for _, offer := range offers {
+		if offer.Locale != locale {
+			return
+		}
+	}
+	ctx.Header("Content-Language", locale)
+}
+
+func getPageRequest(ctx *gin.Context) (dto.PageRequestDTO, error) {
+	page := dto.PageRequestDTO{
+		Cursor: ctx.Query("cursor"),
+		Sort:   ctx.Query("sort"),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return page, errors.New("Limit should be a number")
+		}
+		page.Limit = value
+	}
+
+	if includeTotal := ctx.Query("include_total"); includeTotal != "" {
+		value, err := strconv.ParseBool(includeTotal)
+		if err != nil {
+			return page, errors.New("Include total should be a boolean")
+		}
+		page.IncludeTotal = value
 	}
 
-	return nil
+	return page, nil
 }
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"jobs-ms/src/dto"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobOfferHandlerUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestJobOfferHandlerUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(JobOfferHandlerUnitTestsSuite))
+}
+
+func (suite *JobOfferHandlerUnitTestsSuite) TestSetContentLanguage() {
+	tests := []struct {
+		offers   []*dto.JobOfferResponseDTO
+		expected string
+	}{
+		{[]*dto.JobOfferResponseDTO{{Locale: "de"}, {Locale: "de"}}, "de"},
+		{[]*dto.JobOfferResponseDTO{{Locale: "de"}, {Locale: "en"}}, ""},
+		{[]*dto.JobOfferResponseDTO{{Locale: "en"}}, ""},
+		{[]*dto.JobOfferResponseDTO{}, ""},
+	}
+
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+
+		setContentLanguage(ctx, "de", test.offers...)
+
+		assert.Equal(suite.T(), test.expected, recorder.Header().Get("Content-Language"))
+	}
+}
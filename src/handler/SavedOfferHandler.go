@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"fmt"
+	"jobs-ms/src/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+type SavedOfferHandler struct {
+	Service *service.SavedOfferService
+	Logger  *logrus.Entry
+}
+
+func (handler *SavedOfferHandler) SaveOffer(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /users/:userId/savedOffers/:offerId")
+	defer span.Finish()
+
+	userId, offerId, idErr := getSavedOfferIds(ctx)
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Saving job offer with id %d for user %d", offerId, userId))
+
+	savedOffer, err := handler.Service.Save(userId, offerId)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, savedOffer)
+}
+
+func (handler *SavedOfferHandler) GetSavedOffers(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /users/:userId/savedOffers")
+	defer span.Finish()
+
+	userId, idErr := getId(ctx.Param("userId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+	page, pageErr := getPageRequest(ctx)
+	if pageErr != nil {
+		handler.Logger.Debug(pageErr.Error())
+		ctx.JSON(http.StatusBadRequest, pageErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting saved job offers of user %d", userId))
+
+	savedOffers, err := handler.Service.GetUsersSavedOffers(userId, page)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, savedOffers)
+}
+
+func (handler *SavedOfferHandler) DeleteSavedOffer(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "DELETE /users/:userId/savedOffers/:offerId")
+	defer span.Finish()
+
+	userId, offerId, idErr := getSavedOfferIds(ctx)
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Removing saved job offer with id %d for user %d", offerId, userId))
+
+	if err := handler.Service.Delete(userId, offerId); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func getSavedOfferIds(ctx *gin.Context) (int, int, error) {
+	userId, err := getId(ctx.Param("userId"))
+	if err != nil {
+		return 0, 0, err
+	}
+	offerId, err := getId(ctx.Param("offerId"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return userId, offerId, nil
+}
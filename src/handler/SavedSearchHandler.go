@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+type SavedSearchHandler struct {
+	Service *service.SavedSearchService
+	Logger  *logrus.Entry
+}
+
+func (handler *SavedSearchHandler) AddSavedSearch(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /users/:userId/savedSearches")
+	defer span.Finish()
+
+	userId, idErr := getId(ctx.Param("userId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	var searchDTO dto.SavedSearchRequestDTO
+	if err := ctx.ShouldBindJSON(&searchDTO); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Adding saved search for user %d", userId))
+
+	search, err := handler.Service.Add(userId, &searchDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, search)
+}
+
+func (handler *SavedSearchHandler) GetSavedSearches(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /users/:userId/savedSearches")
+	defer span.Finish()
+
+	userId, idErr := getId(ctx.Param("userId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting saved searches of user %d", userId))
+
+	searches, err := handler.Service.GetUsersSavedSearches(userId)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, searches)
+}
+
+func (handler *SavedSearchHandler) DeleteSavedSearch(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "DELETE /users/:userId/savedSearches/:id")
+	defer span.Finish()
+
+	userId, idErr := getId(ctx.Param("userId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Deleting saved search with id %d of user %d", id, userId))
+
+	if err := handler.Service.Delete(userId, id); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
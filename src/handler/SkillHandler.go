@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"jobs-ms/src/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+type SkillHandler struct {
+	Service *service.SkillService
+	Logger  *logrus.Entry
+}
+
+func (handler *SkillHandler) GetAll(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /skills")
+	defer span.Finish()
+
+	handler.Logger.Info("Getting skills")
+
+	skillsDTO, err := handler.Service.GetAll()
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, skillsDTO)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookHandler struct {
+	Service *service.WebhookService
+	Logger  *logrus.Entry
+}
+
+func (handler *WebhookHandler) AddWebhook(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "POST /webhooks")
+	defer span.Finish()
+
+	var webhookDTO dto.WebhookRequestDTO
+	if err := ctx.ShouldBindJSON(&webhookDTO); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Adding new webhook subscription for company %d", webhookDTO.CompanyID))
+
+	webhook, err := handler.Service.Add(ctx.Request.Context(), &webhookDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+func (handler *WebhookHandler) UpdateWebhook(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "PUT /webhooks/:id")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	var webhookDTO dto.WebhookRequestDTO
+	if err := ctx.ShouldBindJSON(&webhookDTO); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Updating webhook subscription with id %d", id))
+
+	webhook, err := handler.Service.Update(ctx.Request.Context(), id, &webhookDTO)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (handler *WebhookHandler) GetWebhooks(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /webhooks")
+	defer span.Finish()
+
+	companyId, idErr := getId(ctx.Query("companyId"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting webhook subscriptions for company %d", companyId))
+
+	webhooks, err := handler.Service.GetCompanysWebhooks(ctx.Request.Context(), companyId)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+func (handler *WebhookHandler) GetWebhook(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /webhooks/:id")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting webhook subscription with id %d", id))
+
+	webhook, err := handler.Service.GetById(ctx.Request.Context(), id)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (handler *WebhookHandler) DeleteWebhook(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "DELETE /webhooks/:id")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Deleting webhook subscription with id %d", id))
+
+	if err := handler.Service.Delete(ctx.Request.Context(), id); err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusBadRequest), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func (handler *WebhookHandler) GetDeliveries(ctx *gin.Context) {
+	span, _ := opentracing.StartSpanFromContext(ctx.Request.Context(), "GET /webhooks/:id/deliveries")
+	defer span.Finish()
+
+	id, idErr := getId(ctx.Param("id"))
+	if idErr != nil {
+		handler.Logger.Debug(idErr.Error())
+		ctx.JSON(http.StatusBadRequest, idErr.Error())
+		return
+	}
+
+	limit := 0
+	if value := ctx.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			handler.Logger.Debug(err.Error())
+			ctx.JSON(http.StatusBadRequest, "Limit should be a number")
+			return
+		}
+	}
+
+	handler.Logger.Info(fmt.Sprintf("Getting deliveries of webhook subscription with id %d", id))
+
+	deliveries, err := handler.Service.GetDeliveries(ctx.Request.Context(), id, ctx.Query("status"), limit)
+	if err != nil {
+		handler.Logger.Debug(err.Error())
+		ctx.JSON(getErrorStatus(err, http.StatusInternalServerError), err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
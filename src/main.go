@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/events"
 	"jobs-ms/src/handler"
-	"jobs-ms/src/model"
 	"jobs-ms/src/repository"
 	"jobs-ms/src/service"
 	"jobs-ms/src/utils"
@@ -49,7 +51,9 @@ func initDB() (*gorm.DB, error) {
 		panic("failed to connect database")
 	}
 
-	db.AutoMigrate(model.JobOffer{})
+	if err := repository.Migrate(db); err != nil {
+		panic("failed to migrate database")
+	}
 	return db, err
 }
 
@@ -71,24 +75,377 @@ func InitJaeger() (opentracing.Tracer, io.Closer, error) {
 }
 
 func initOfferRepo(database *gorm.DB) *repository.JobOfferRepository {
-	return &repository.JobOfferRepository{Database: database}
+	return &repository.JobOfferRepository{Database: database, PostGIS: repository.HasPostGIS(database)}
+}
+
+func initOfferService(repo *repository.JobOfferRepository, database *gorm.DB) *service.JobOfferService {
+	return &service.JobOfferService{
+		JobOfferRepo: repo,
+		MemberRepo:   &repository.CompanyMemberRepository{Database: database},
+		AuditRepo:    &repository.AuditRepository{Database: database},
+		Logger:       utils.Logger(),
+	}
+}
+
+func initApiTokenRepo(database *gorm.DB) *repository.ApiTokenRepository {
+	return &repository.ApiTokenRepository{Database: database}
+}
+
+func initApiTokenService(repo *repository.ApiTokenRepository, database *gorm.DB) *service.ApiTokenService {
+	return &service.ApiTokenService{
+		ApiTokenRepo: repo,
+		MemberRepo:   &repository.CompanyMemberRepository{Database: database},
+		AuditRepo:    &repository.AuditRepository{Database: database},
+		Logger:       utils.Logger(),
+	}
 }
 
-func initOfferService(repo *repository.JobOfferRepository) *service.JobOfferService {
-	return &service.JobOfferService{JobOfferRepo: repo, Logger: utils.Logger()}
+func initApiTokenHandler(apiTokenService *service.ApiTokenService) *handler.ApiTokenHandler {
+	return &handler.ApiTokenHandler{Service: apiTokenService, Logger: utils.Logger()}
 }
 
 func initOfferHandler(service *service.JobOfferService) *handler.JobOfferHandler {
 	return &handler.JobOfferHandler{Service: service, Logger: utils.Logger()}
 }
 
-func handleOfferFunc(handler *handler.JobOfferHandler, router *gin.Engine) {
-	router.POST("/jobOffers", handler.AddJobOffer)
+// getInterval reads the interval of a background job from the environment
+// variable. The fallback is used when it is not set, not a duration or not
+// positive.
+func getInterval(name string, fallback time.Duration) time.Duration {
+	text := os.Getenv(name)
+	if text == "" {
+		return fallback
+	}
+
+	interval, err := time.ParseDuration(text)
+	if err != nil || interval <= 0 {
+		utils.Logger().Warn(fmt.Sprintf("%s should be a positive duration, using %s", name, fallback))
+		return fallback
+	}
+	return interval
+}
+
+func initExpirySweeper(offerService *service.JobOfferService) *service.ExpirySweeper {
+	interval := getInterval("EXPIRY_SWEEP_INTERVAL", time.Minute)
+
+	return &service.ExpirySweeper{
+		Service:  offerService,
+		Interval: interval,
+		Logger:   utils.Logger(),
+	}
+}
+
+// initTrashPurger purges offers that were in the trash for longer than
+// TRASH_RETENTION_DAYS. 30 days are used when it is not set or not a positive
+// number, so that offers are never purged right after they are trashed.
+func initTrashPurger(offerService *service.JobOfferService) *service.TrashPurger {
+	retentionDays := 30
+	if text := os.Getenv("TRASH_RETENTION_DAYS"); text != "" {
+		days, err := strconv.Atoi(text)
+		if err != nil || days <= 0 {
+			utils.Logger().Warn(fmt.Sprintf("TRASH_RETENTION_DAYS should be a positive number of days, using %d", retentionDays))
+		} else {
+			retentionDays = days
+		}
+	}
+
+	return &service.TrashPurger{
+		Service:   offerService,
+		Retention: time.Duration(retentionDays) * 24 * time.Hour,
+		Interval:  time.Hour,
+		Logger:    utils.Logger(),
+	}
+}
+
+func initOutboxRepo(database *gorm.DB) *repository.OutboxRepository {
+	return &repository.OutboxRepository{Database: database}
+}
+
+// initCurrencyRates replaces the built-in rates salaries are compared with by
+// the ones in SALARY_CURRENCY_RATES, given as CODE=rate pairs in EUR.
+func initCurrencyRates() {
+	text := os.Getenv("SALARY_CURRENCY_RATES")
+	if text == "" {
+		return
+	}
+
+	rates, err := utils.ParseCurrencyRates(text)
+	if err != nil {
+		panic(err)
+	}
+	utils.CurrencyRates = rates
+}
+
+// initLocales sets the locale offers are written in from DEFAULT_LOCALE and
+// the locales they can be translated to from SUPPORTED_LOCALES, a comma
+// separated list. The default locale is always supported.
+func initLocales() {
+	if locale := os.Getenv("DEFAULT_LOCALE"); locale != "" {
+		utils.DefaultLocale = utils.NormalizeLocale(locale)
+	}
+
+	utils.SupportedLocales = []string{}
+	if text := os.Getenv("SUPPORTED_LOCALES"); text != "" {
+		locales, err := utils.ParseLocales(text)
+		if err != nil {
+			panic(err)
+		}
+		utils.SupportedLocales = locales
+	}
+	if !utils.IsSupportedLocale(utils.DefaultLocale) {
+		utils.SupportedLocales = append([]string{utils.DefaultLocale}, utils.SupportedLocales...)
+	}
+}
+
+// initAuthMiddleware verifies tokens with the keys of the JWKS file at
+// JWT_JWKS_FILE, or with the single key in JWT_STATIC_KEY, which is either a
+// PEM encoded public key or an HMAC secret. Without either every JWT is
+// rejected. API tokens are verified by apiTokens.
+func initAuthMiddleware(apiTokens auth.ApiTokenVerifier) *handler.AuthMiddleware {
+	authenticator := auth.Authenticator{Issuer: os.Getenv("JWT_ISSUER"), Audience: os.Getenv("JWT_AUDIENCE")}
+
+	var err error
+	if path := os.Getenv("JWT_JWKS_FILE"); path != "" {
+		authenticator.Keys, err = auth.LoadJWKS(path)
+	} else if key := os.Getenv("JWT_STATIC_KEY"); key != "" {
+		authenticator.Keys, err = auth.NewStaticKeySet(key)
+	} else {
+		utils.Logger().Warn("Neither JWT_JWKS_FILE nor JWT_STATIC_KEY is set, every JWT will be rejected")
+	}
+	if err != nil {
+		panic(fmt.Sprintf("failed to load JWT keys: %s", err.Error()))
+	}
+
+	return &handler.AuthMiddleware{Authenticator: &authenticator, ApiTokens: apiTokens, Logger: utils.Logger()}
+}
+
+// initEventPublisher creates the publisher chosen by EVENTS_PUBLISHER, which
+// is one of http (the default), nats or memory.
+func initEventPublisher() events.Publisher {
+	switch os.Getenv("EVENTS_PUBLISHER") {
+	case "", "http":
+		publisher, err := events.NewHTTPPublisher(os.Getenv("EVENTS_MS"), &http.Client{Timeout: 10 * time.Second}, os.Getenv("EVENTS_HTTP_MODE"))
+		if err != nil {
+			panic(fmt.Sprintf("failed to configure events publisher: %s", err.Error()))
+		}
+		return publisher
+	case "nats":
+		subject := os.Getenv("NATS_SUBJECT")
+		if subject == "" {
+			subject = "jobs.events"
+		}
+
+		publisher, err := events.NewNATSPublisher(os.Getenv("NATS_URL"), subject, 10*time.Second)
+		if err != nil {
+			panic(fmt.Sprintf("failed to configure message broker: %s", err.Error()))
+		}
+		return publisher
+	case "memory":
+		return events.NewMemoryPublisher()
+	default:
+		panic(fmt.Sprintf("unknown events publisher %s", os.Getenv("EVENTS_PUBLISHER")))
+	}
+}
+
+func initOutboxRelay(repo *repository.OutboxRepository, publisher events.Publisher) *service.OutboxRelay {
+	interval := getInterval("OUTBOX_RELAY_INTERVAL", 5*time.Second)
+
+	maxAttempts, err := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS"))
+	if err != nil {
+		maxAttempts = 10
+	}
+
+	return &service.OutboxRelay{
+		OutboxRepo:    repo,
+		Publisher:     publisher,
+		Interval:      interval,
+		RetryDelay:    interval,
+		MaxRetryDelay: time.Hour,
+		MaxAttempts:   maxAttempts,
+		BatchSize:     100,
+		Logger:        utils.Logger(),
+	}
+}
+
+func initWebhookRepo(database *gorm.DB) *repository.WebhookRepository {
+	return &repository.WebhookRepository{Database: database}
+}
+
+func initWebhookService(repo *repository.WebhookRepository, database *gorm.DB) *service.WebhookService {
+	return &service.WebhookService{
+		WebhookRepo: repo,
+		MemberRepo:  &repository.CompanyMemberRepository{Database: database},
+		AuditRepo:   &repository.AuditRepository{Database: database},
+		Logger:      utils.Logger(),
+	}
+}
+
+func initWebhookHandler(webhookService *service.WebhookService) *handler.WebhookHandler {
+	return &handler.WebhookHandler{Service: webhookService, Logger: utils.Logger()}
+}
+
+func initWebhookDispatcher(repo *repository.WebhookRepository) *service.WebhookDispatcher {
+	interval := getInterval("WEBHOOK_DISPATCH_INTERVAL", 5*time.Second)
+
+	maxAttempts, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	if err != nil {
+		maxAttempts = 8
+	}
+
+	return &service.WebhookDispatcher{
+		WebhookRepo:   repo,
+		Client:        service.NewWebhookClient(10 * time.Second),
+		Interval:      interval,
+		RetryDelay:    30 * time.Second,
+		MaxRetryDelay: 6 * time.Hour,
+		MaxAttempts:   maxAttempts,
+		BatchSize:     100,
+		Logger:        utils.Logger(),
+	}
+}
+
+func initApplicationRepo(database *gorm.DB) *repository.ApplicationRepository {
+	return &repository.ApplicationRepository{Database: database}
+}
+
+func initApplicationService(repo *repository.ApplicationRepository, offerRepo *repository.JobOfferRepository, database *gorm.DB) *service.ApplicationService {
+	return &service.ApplicationService{
+		ApplicationRepo: repo,
+		JobOfferRepo:    offerRepo,
+		MemberRepo:      &repository.CompanyMemberRepository{Database: database},
+		AuditRepo:       &repository.AuditRepository{Database: database},
+		Logger:          utils.Logger(),
+	}
+}
+
+func initApplicationHandler(applicationService *service.ApplicationService) *handler.ApplicationHandler {
+	return &handler.ApplicationHandler{Service: applicationService, Logger: utils.Logger()}
+}
+
+func initSavedOfferRepo(database *gorm.DB) *repository.SavedOfferRepository {
+	return &repository.SavedOfferRepository{Database: database}
+}
+
+func initSavedOfferService(repo *repository.SavedOfferRepository, offerRepo *repository.JobOfferRepository) *service.SavedOfferService {
+	return &service.SavedOfferService{SavedOfferRepo: repo, JobOfferRepo: offerRepo, Logger: utils.Logger()}
+}
+
+func initSavedOfferHandler(savedOfferService *service.SavedOfferService) *handler.SavedOfferHandler {
+	return &handler.SavedOfferHandler{Service: savedOfferService, Logger: utils.Logger()}
+}
+
+func initSavedSearchRepo(database *gorm.DB) *repository.SavedSearchRepository {
+	return &repository.SavedSearchRepository{Database: database}
+}
+
+func initSavedSearchService(repo *repository.SavedSearchRepository) *service.SavedSearchService {
+	return &service.SavedSearchService{SavedSearchRepo: repo, Logger: utils.Logger()}
+}
+
+func initSavedSearchHandler(savedSearchService *service.SavedSearchService) *handler.SavedSearchHandler {
+	return &handler.SavedSearchHandler{Service: savedSearchService, Logger: utils.Logger()}
+}
+
+func initJobAlertRepo(database *gorm.DB) *repository.JobAlertRepository {
+	return &repository.JobAlertRepository{Database: database}
+}
+
+func initJobAlertService(repo *repository.JobAlertRepository) *service.JobAlertService {
+	return &service.JobAlertService{JobAlertRepo: repo, Logger: utils.Logger()}
+}
+
+func initJobAlertHandler(jobAlertService *service.JobAlertService) *handler.JobAlertHandler {
+	return &handler.JobAlertHandler{Service: jobAlertService, Logger: utils.Logger()}
+}
+
+func initAlertMatcher(alertRepo *repository.JobAlertRepository, searchRepo *repository.SavedSearchRepository, offerRepo *repository.JobOfferRepository) *service.AlertMatcher {
+	interval := getInterval("ALERT_MATCH_INTERVAL", 30*time.Second)
+
+	return &service.AlertMatcher{
+		JobAlertRepo:    alertRepo,
+		SavedSearchRepo: searchRepo,
+		JobOfferRepo:    offerRepo,
+		Interval:        interval,
+		RetryDelay:      time.Minute,
+		MaxRetryDelay:   6 * time.Hour,
+		BatchSize:       100,
+		Logger:          utils.Logger(),
+	}
+}
+
+func initSkillRepo(database *gorm.DB) *repository.SkillRepository {
+	return &repository.SkillRepository{Database: database}
+}
+
+func initSkillService(repo *repository.SkillRepository) *service.SkillService {
+	return &service.SkillService{SkillRepo: repo, Logger: utils.Logger()}
+}
+
+func initSkillHandler(service *service.SkillService) *handler.SkillHandler {
+	return &handler.SkillHandler{Service: service, Logger: utils.Logger()}
+}
+
+func handleSkillFunc(handler *handler.SkillHandler, router *gin.Engine) {
+	router.GET("/skills", handler.GetAll)
+}
+
+func handleApplicationFunc(handler *handler.ApplicationHandler, router *gin.Engine, admin gin.HandlerFunc, candidate gin.HandlerFunc, user gin.HandlerFunc) {
+	router.POST("/jobOffers/:id/applications", candidate, handler.Apply)
+	router.GET("/jobOffers/:id/applications", admin, handler.GetJobOffersApplications)
+	router.GET("/users/:userId/applications", user, handler.GetCandidatesApplications)
+	router.POST("/applications/:id/stage", admin, handler.ChangeStage)
+	router.GET("/applications/:id/history", admin, handler.GetHistory)
+}
+
+func handleSavedOfferFunc(handler *handler.SavedOfferHandler, router *gin.Engine, user gin.HandlerFunc) {
+	router.GET("/users/:userId/savedOffers", user, handler.GetSavedOffers)
+	router.POST("/users/:userId/savedOffers/:offerId", user, handler.SaveOffer)
+	router.DELETE("/users/:userId/savedOffers/:offerId", user, handler.DeleteSavedOffer)
+}
+
+func handleSavedSearchFunc(handler *handler.SavedSearchHandler, router *gin.Engine, user gin.HandlerFunc) {
+	router.POST("/users/:userId/savedSearches", user, handler.AddSavedSearch)
+	router.GET("/users/:userId/savedSearches", user, handler.GetSavedSearches)
+	router.DELETE("/users/:userId/savedSearches/:id", user, handler.DeleteSavedSearch)
+}
+
+func handleJobAlertFunc(handler *handler.JobAlertHandler, router *gin.Engine, user gin.HandlerFunc) {
+	router.GET("/users/:userId/alerts", user, handler.GetAlerts)
+	router.POST("/users/:userId/alerts/:id/dismiss", user, handler.DismissAlert)
+}
+
+func handleWebhookFunc(handler *handler.WebhookHandler, router *gin.Engine, admin gin.HandlerFunc) {
+	router.POST("/webhooks", admin, handler.AddWebhook)
+	router.GET("/webhooks", admin, handler.GetWebhooks)
+	router.GET("/webhooks/:id", admin, handler.GetWebhook)
+	router.PUT("/webhooks/:id", admin, handler.UpdateWebhook)
+	router.DELETE("/webhooks/:id", admin, handler.DeleteWebhook)
+	router.GET("/webhooks/:id/deliveries", admin, handler.GetDeliveries)
+}
+
+func handleApiTokenFunc(handler *handler.ApiTokenHandler, router *gin.Engine, admin gin.HandlerFunc) {
+	router.POST("/companies/:companyId/apiTokens", admin, handler.AddApiToken)
+	router.GET("/companies/:companyId/apiTokens", admin, handler.GetApiTokens)
+	router.DELETE("/companies/:companyId/apiTokens/:id", admin, handler.RevokeApiToken)
+}
+
+func handleOfferFunc(handler *handler.JobOfferHandler, router *gin.Engine, admin gin.HandlerFunc, publisher gin.HandlerFunc) {
+	router.POST("/jobOffers", publisher, handler.AddJobOffer)
 	router.GET("/jobOffers", handler.GetAll)
 	router.GET("/jobOffers/company/:companyId", handler.GetJobOffersByCompany)
 	router.GET("/jobOffers/search", handler.Search)
+	router.POST("/jobOffers/recommendations", handler.Recommend)
+	router.GET("/jobOffers/trash", admin, handler.GetTrash)
 	router.GET("/jobOffers/:id", handler.GetJobOffer)
-	router.DELETE("/jobOffers/:id", handler.DeleteJobOffer)
+	router.PUT("/jobOffers/:id", admin, handler.UpdateJobOffer)
+	router.PATCH("/jobOffers/:id", admin, handler.PatchJobOffer)
+	router.POST("/jobOffers/:id/publish", admin, handler.PublishJobOffer)
+	router.POST("/jobOffers/:id/close", admin, handler.CloseJobOffer)
+	router.POST("/jobOffers/:id/restore", admin, handler.RestoreJobOffer)
+	router.GET("/jobOffers/:id/revisions", admin, handler.GetRevisions)
+	router.POST("/jobOffers/:id/revisions/:rev/revert", admin, handler.RevertJobOffer)
+	router.PUT("/jobOffers/:id/translations/:locale", admin, handler.SaveTranslation)
+	router.DELETE("/jobOffers/:id", admin, handler.DeleteJobOffer)
 }
 
 var totalTrafficSizeInGB = prometheus.NewCounter(
@@ -198,23 +555,84 @@ func main() {
 		opentracing.SetGlobalTracer(tracer)
 	}
 
+	initCurrencyRates()
+	initLocales()
+
 	offerRepo := initOfferRepo(database)
-	offerService := initOfferService(offerRepo)
+	offerService := initOfferService(offerRepo, database)
 	offerHandler := initOfferHandler(offerService)
 
+	expirySweeper := initExpirySweeper(offerService)
+	expirySweeper.Start(context.Background())
+
+	applicationRepo := initApplicationRepo(database)
+	applicationService := initApplicationService(applicationRepo, offerRepo, database)
+	applicationHandler := initApplicationHandler(applicationService)
+
+	savedOfferRepo := initSavedOfferRepo(database)
+	savedOfferService := initSavedOfferService(savedOfferRepo, offerRepo)
+	savedOfferHandler := initSavedOfferHandler(savedOfferService)
+
+	savedSearchRepo := initSavedSearchRepo(database)
+	savedSearchService := initSavedSearchService(savedSearchRepo)
+	savedSearchHandler := initSavedSearchHandler(savedSearchService)
+
+	jobAlertRepo := initJobAlertRepo(database)
+	jobAlertService := initJobAlertService(jobAlertRepo)
+	jobAlertHandler := initJobAlertHandler(jobAlertService)
+
+	alertMatcher := initAlertMatcher(jobAlertRepo, savedSearchRepo, offerRepo)
+	alertMatcher.Start(context.Background())
+
+	webhookRepo := initWebhookRepo(database)
+	webhookService := initWebhookService(webhookRepo, database)
+	webhookHandler := initWebhookHandler(webhookService)
+
+	webhookDispatcher := initWebhookDispatcher(webhookRepo)
+	webhookDispatcher.Start(context.Background())
+
+	outboxRepo := initOutboxRepo(database)
+	eventPublisher := events.MultiPublisher{initEventPublisher(), webhookService, jobAlertService}
+	outboxRelay := initOutboxRelay(outboxRepo, eventPublisher)
+	outboxRelay.Start(context.Background())
+
+	trashPurger := initTrashPurger(offerService)
+	trashPurger.Start(context.Background())
+
+	apiTokenRepo := initApiTokenRepo(database)
+	apiTokenService := initApiTokenService(apiTokenRepo, database)
+	apiTokenHandler := initApiTokenHandler(apiTokenService)
+
+	skillRepo := initSkillRepo(database)
+	skillService := initSkillService(skillRepo)
+	skillHandler := initSkillHandler(skillService)
+
 	router := gin.Default()
 
 	setupPrometherus()
 
 	router.Use(prometheusMiddleware())
 
+	authMiddleware := initAuthMiddleware(apiTokenService)
+	router.Use(authMiddleware.Authenticate)
+
 	router.GET("/api/metrics", prometheusGin())
 
-	handleOfferFunc(offerHandler, router)
+	admin := authMiddleware.RequireRole(auth.RoleCompanyAdmin, auth.RolePlatformAdmin)
+	handleOfferFunc(offerHandler, router, admin, authMiddleware.RequireRoleOrScope(auth.ScopeCreateJobOffers, auth.RoleCompanyAdmin, auth.RolePlatformAdmin))
+	handleApiTokenFunc(apiTokenHandler, router, admin)
+	handleSkillFunc(skillHandler, router)
+	handleWebhookFunc(webhookHandler, router, admin)
+	user := authMiddleware.RequireUser("userId")
+	handleApplicationFunc(applicationHandler, router, admin, authMiddleware.RequireUser(""), user)
+	handleSavedOfferFunc(savedOfferHandler, router, user)
+	handleSavedSearchFunc(savedSearchHandler, router, user)
+	handleJobAlertFunc(jobAlertHandler, router, user)
 
 	logger.Info(fmt.Sprintf("Starting server on port %s", os.Getenv("SERVER_PORT")))
 	http.ListenAndServe(port, cors.New(cors.Options{
 		AllowedOrigins: []string{"http://localhost:9094"},
-		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodPut},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodPut, http.MethodPatch},
+		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization"},
 	}).Handler(router))
 }
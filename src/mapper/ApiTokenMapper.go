@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+)
+
+func ApiTokenToApiTokenResponseDTO(token *model.ApiToken) *dto.ApiTokenResponseDTO {
+	var result dto.ApiTokenResponseDTO
+
+	result.ID = token.ID
+	result.CompanyID = token.CompanyID
+	result.Name = token.Name
+	result.Prefix = token.Prefix
+	result.Scopes = append([]string{}, token.Scopes...)
+	result.ExpiresAt = token.ExpiresAt
+	result.LastUsedAt = token.LastUsedAt
+	result.RevokedAt = token.RevokedAt
+	result.CreatedAt = token.CreatedAt
+
+	return &result
+}
+
+func ApiTokenRequestDTOToApiToken(companyId int, request *dto.ApiTokenRequestDTO) *model.ApiToken {
+	var token model.ApiToken
+
+	token.CompanyID = companyId
+	token.Name = request.Name
+	token.Scopes = append([]string{}, request.Scopes...)
+	token.ExpiresAt = request.ExpiresAt
+
+	return &token
+}
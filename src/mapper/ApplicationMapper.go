@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+)
+
+func ApplicationToApplicationResponseDTO(application *model.Application) *dto.ApplicationResponseDTO {
+	var result dto.ApplicationResponseDTO
+
+	result.ID = application.ID
+	result.JobOfferID = application.JobOfferID
+	result.CandidateID = application.CandidateID
+	result.CoverLetter = application.CoverLetter
+	result.Stage = application.Stage
+	result.CreatedAt = application.CreatedAt
+	result.UpdatedAt = application.UpdatedAt
+	if application.JobOffer.ID != 0 {
+		result.JobOffer = JobOfferToJobOfferResponseDTO(&application.JobOffer)
+	}
+
+	return &result
+}
+
+func ApplicationRequestDTOToApplication(jobOfferId int, candidateId int, application *dto.ApplicationRequestDTO) *model.Application {
+	var result model.Application
+
+	result.JobOfferID = jobOfferId
+	result.CandidateID = candidateId
+	result.CoverLetter = application.CoverLetter
+
+	return &result
+}
+
+func ApplicationStageChangeToApplicationStageChangeResponseDTO(change *model.ApplicationStageChange) *dto.ApplicationStageChangeResponseDTO {
+	var result dto.ApplicationStageChangeResponseDTO
+
+	result.FromStage = change.FromStage
+	result.ToStage = change.ToStage
+	result.Note = change.Note
+	result.CreatedAt = change.CreatedAt
+
+	return &result
+}
@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"encoding/json"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+)
+
+func SavedSearchToSavedSearchResponseDTO(search *model.SavedSearch) *dto.SavedSearchResponseDTO {
+	var result dto.SavedSearchResponseDTO
+
+	result.ID = search.ID
+	result.UserID = search.UserID
+	result.Name = search.Name
+	result.Query = search.Query
+	result.Filters = map[string][]string{}
+	if search.Filters != "" {
+		_ = json.Unmarshal([]byte(search.Filters), &result.Filters)
+	}
+	result.CreatedAt = search.CreatedAt
+
+	return &result
+}
+
+func JobAlertToJobAlertResponseDTO(alert *model.JobAlert) *dto.JobAlertResponseDTO {
+	var result dto.JobAlertResponseDTO
+
+	result.ID = alert.ID
+	result.SavedSearchID = alert.SavedSearchID
+	result.SavedSearchName = alert.SavedSearch.Name
+	result.JobOffer = JobOfferToJobOfferResponseDTO(&alert.JobOffer)
+	result.CreatedAt = alert.CreatedAt
+	result.DismissedAt = alert.DismissedAt
+
+	return &result
+}
+
+func JobAlertPageToJobAlertPageDTO(page *repository.JobAlertPage) *dto.JobAlertPageDTO {
+	var result dto.JobAlertPageDTO
+
+	result.Items = make([]*dto.JobAlertResponseDTO, len(page.Alerts))
+	for i := 0; i < len(page.Alerts); i++ {
+		result.Items[i] = JobAlertToJobAlertResponseDTO(page.Alerts[i])
+	}
+	if page.Next != nil {
+		result.NextCursor = page.Next.Encode()
+	}
+	result.HasMore = page.HasMore
+	result.Total = page.Total
+
+	return &result
+}
@@ -3,6 +3,9 @@ package mapper
 import (
 	"jobs-ms/src/dto"
 	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"strings"
 )
 
 func JobOfferToJobOfferResponseDTO(jobOffer *model.JobOffer) *dto.JobOfferResponseDTO {
@@ -14,11 +17,91 @@ func JobOfferToJobOfferResponseDTO(jobOffer *model.JobOffer) *dto.JobOfferRespon
 	offer.DailyActivitiesDescription = jobOffer.DailyActivitiesDescription
 	offer.Link = jobOffer.Link
 	offer.Position = jobOffer.Position
-	offer.Skills = jobOffer.Skills
+	offer.Skills = SkillsToSkillNames(jobOffer.Skills)
+	offer.Status = jobOffer.Status
+	offer.ValidFrom = jobOffer.ValidFrom
+	offer.ValidUntil = jobOffer.ValidUntil
+	offer.Stages = jobOffer.ApplicationStages()
+	offer.SalaryMin = jobOffer.SalaryMin
+	offer.SalaryMax = jobOffer.SalaryMax
+	offer.Currency = jobOffer.Currency
+	offer.Period = jobOffer.SalaryPeriod
+	offer.City = jobOffer.City
+	offer.Country = jobOffer.Country
+	offer.Latitude = jobOffer.Latitude
+	offer.Longitude = jobOffer.Longitude
+	offer.RemotePolicy = jobOffer.RemotePolicy
+	offer.EmploymentType = jobOffer.EmploymentType
+	offer.Seniority = jobOffer.Seniority
+	offer.WorkSchedule = jobOffer.WorkSchedule
+	offer.CreatedAt = jobOffer.CreatedAt
+	offer.DeletedAt = jobOffer.DeletedAt
+	offer.Relevance = jobOffer.Relevance
+	offer.Locale = utils.DefaultLocale
 
 	return &offer
 }
 
+// TranslateJobOfferResponseDTO replaces the text fields of the offer with the
+// ones of its translation. Skills are only replaced when translated.
+func TranslateJobOfferResponseDTO(offer *dto.JobOfferResponseDTO, translation *model.JobOfferTranslation) {
+	offer.Position = translation.Position
+	offer.JobDescription = translation.JobDescription
+	offer.DailyActivitiesDescription = translation.DailyActivitiesDescription
+	if len(translation.Skills) > 0 {
+		offer.Skills = translation.Skills
+	}
+	offer.Locale = translation.Locale
+}
+
+func JobOfferTranslationRequestDTOToJobOfferTranslation(id int, locale string, translationDTO *dto.JobOfferTranslationRequestDTO) *model.JobOfferTranslation {
+	var translation model.JobOfferTranslation
+
+	translation.JobOfferID = id
+	translation.Locale = locale
+	translation.Position = translationDTO.Position
+	translation.JobDescription = translationDTO.JobDescription
+	translation.DailyActivitiesDescription = translationDTO.DailyActivitiesDescription
+	for _, skill := range translationDTO.Skills {
+		translation.Skills = append(translation.Skills, strings.TrimSpace(skill))
+	}
+
+	return &translation
+}
+
+func JobOfferTranslationToJobOfferTranslationResponseDTO(translation *model.JobOfferTranslation) *dto.JobOfferTranslationResponseDTO {
+	var result dto.JobOfferTranslationResponseDTO
+
+	result.JobOfferID = translation.JobOfferID
+	result.Locale = translation.Locale
+	result.Position = translation.Position
+	result.JobDescription = translation.JobDescription
+	result.DailyActivitiesDescription = translation.DailyActivitiesDescription
+	result.Skills = translation.Skills
+	if result.Skills == nil {
+		result.Skills = []string{}
+	}
+	result.UpdatedAt = translation.UpdatedAt
+
+	return &result
+}
+
+func JobOfferPageToJobOfferPageDTO(page *repository.JobOfferPage) *dto.JobOfferPageDTO {
+	var result dto.JobOfferPageDTO
+
+	result.Items = make([]*dto.JobOfferResponseDTO, len(page.Offers))
+	for i := 0; i < len(page.Offers); i++ {
+		result.Items[i] = JobOfferToJobOfferResponseDTO(page.Offers[i])
+	}
+	if page.Next != nil {
+		result.NextCursor = page.Next.Encode()
+	}
+	result.HasMore = page.HasMore
+	result.Total = page.Total
+
+	return &result
+}
+
 func JobOfferRequestDTOToJobOffer(jobOffer *dto.JobOfferRequestDTO) *model.JobOffer {
 	var offer model.JobOffer
 
@@ -27,7 +110,66 @@ func JobOfferRequestDTOToJobOffer(jobOffer *dto.JobOfferRequestDTO) *model.JobOf
 	offer.DailyActivitiesDescription = jobOffer.DailyActivitiesDescription
 	offer.Link = jobOffer.Link
 	offer.Position = jobOffer.Position
-	offer.Skills = jobOffer.Skills
+	offer.Status = jobOffer.Status
+	offer.ValidFrom = jobOffer.ValidFrom
+	offer.ValidUntil = jobOffer.ValidUntil
+	offer.Stages = jobOffer.Stages
+	offer.SalaryMin = jobOffer.SalaryMin
+	offer.SalaryMax = jobOffer.SalaryMax
+	offer.Currency = jobOffer.Currency
+	offer.SalaryPeriod = jobOffer.Period
+	offer.City = jobOffer.City
+	offer.Country = strings.ToUpper(jobOffer.Country)
+	offer.Latitude = jobOffer.Latitude
+	offer.Longitude = jobOffer.Longitude
+	offer.RemotePolicy = jobOffer.RemotePolicy
+	offer.EmploymentType = jobOffer.EmploymentType
+	offer.Seniority = jobOffer.Seniority
+	offer.WorkSchedule = jobOffer.WorkSchedule
+	for _, name := range utils.NormalizeSkills(jobOffer.Skills) {
+		offer.Skills = append(offer.Skills, model.Skill{Name: name})
+	}
 
 	return &offer
 }
+
+func JobOfferToJobOfferRequestDTO(jobOffer *model.JobOffer) *dto.JobOfferRequestDTO {
+	var offer dto.JobOfferRequestDTO
+
+	offer.CompanyID = jobOffer.CompanyID
+	offer.JobDescription = jobOffer.JobDescription
+	offer.DailyActivitiesDescription = jobOffer.DailyActivitiesDescription
+	offer.Link = jobOffer.Link
+	offer.Position = jobOffer.Position
+	offer.Skills = SkillsToSkillNames(jobOffer.Skills)
+	offer.ValidFrom = jobOffer.ValidFrom
+	offer.ValidUntil = jobOffer.ValidUntil
+	offer.Stages = jobOffer.Stages
+	offer.SalaryMin = jobOffer.SalaryMin
+	offer.SalaryMax = jobOffer.SalaryMax
+	offer.Currency = jobOffer.Currency
+	offer.Period = jobOffer.SalaryPeriod
+	offer.City = jobOffer.City
+	offer.Country = jobOffer.Country
+	offer.Latitude = jobOffer.Latitude
+	offer.Longitude = jobOffer.Longitude
+	offer.RemotePolicy = jobOffer.RemotePolicy
+	offer.EmploymentType = jobOffer.EmploymentType
+	offer.Seniority = jobOffer.Seniority
+	offer.WorkSchedule = jobOffer.WorkSchedule
+
+	return &offer
+}
+
+func JobOfferRevisionToJobOfferRevisionResponseDTO(revision *model.JobOfferRevision, changes []dto.FieldChangeDTO) *dto.JobOfferRevisionResponseDTO {
+	var result dto.JobOfferRevisionResponseDTO
+
+	result.Revision = revision.Revision
+	result.Action = revision.Action
+	result.SourceRevision = revision.SourceRevision
+	result.ChangedBy = revision.ChangedBy
+	result.CreatedAt = revision.CreatedAt
+	result.Changes = changes
+
+	return &result
+}
@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+)
+
+const (
+	UnavailableDeleted = "deleted"
+	UnavailableClosed  = "closed"
+	UnavailableExpired = "expired"
+)
+
+func SavedOfferToSavedOfferResponseDTO(savedOffer *model.SavedOffer) *dto.SavedOfferResponseDTO {
+	var result dto.SavedOfferResponseDTO
+
+	if savedOffer.JobOffer.ID == 0 {
+		// The offer was purged from the trash, only its id is left.
+		result.JobOfferResponseDTO = &dto.JobOfferResponseDTO{ID: savedOffer.JobOfferID}
+	} else {
+		result.JobOfferResponseDTO = JobOfferToJobOfferResponseDTO(&savedOffer.JobOffer)
+	}
+	result.SavedAt = savedOffer.CreatedAt
+	result.UnavailableReason = unavailableReason(&savedOffer.JobOffer)
+	result.Available = result.UnavailableReason == ""
+
+	return &result
+}
+
+func SavedOfferPageToSavedOfferPageDTO(page *repository.SavedOfferPage) *dto.SavedOfferPageDTO {
+	var result dto.SavedOfferPageDTO
+
+	result.Items = make([]*dto.SavedOfferResponseDTO, len(page.SavedOffers))
+	for i := 0; i < len(page.SavedOffers); i++ {
+		result.Items[i] = SavedOfferToSavedOfferResponseDTO(page.SavedOffers[i])
+	}
+	if page.Next != nil {
+		result.NextCursor = page.Next.Encode()
+	}
+	result.HasMore = page.HasMore
+	result.Total = page.Total
+
+	return &result
+}
+
+// unavailableReason tells why candidates can no longer apply to a saved
+// offer, or returns an empty string when they still can.
+func unavailableReason(offer *model.JobOffer) string {
+	switch {
+	case offer.ID == 0, offer.DeletedAt != nil:
+		return UnavailableDeleted
+	case offer.Status == model.JobOfferStatusClosed:
+		return UnavailableClosed
+	case offer.Status == model.JobOfferStatusExpired:
+		return UnavailableExpired
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,25 @@
+package mapper
+
+import (
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+)
+
+func SkillToSkillResponseDTO(skill *model.Skill) *dto.SkillResponseDTO {
+	var response dto.SkillResponseDTO
+
+	response.ID = skill.ID
+	response.Name = skill.Name
+	response.OfferCount = skill.OfferCount
+
+	return &response
+}
+
+func SkillsToSkillNames(skills []model.Skill) []string {
+	names := make([]string, len(skills))
+	for i, skill := range skills {
+		names[i] = skill.Name
+	}
+
+	return names
+}
@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+)
+
+func WebhookSubscriptionToWebhookResponseDTO(subscription *model.WebhookSubscription) *dto.WebhookResponseDTO {
+	var webhook dto.WebhookResponseDTO
+
+	webhook.ID = subscription.ID
+	webhook.CompanyID = subscription.CompanyID
+	webhook.URL = subscription.URL
+	webhook.EventTypes = append([]string{}, subscription.EventTypes...)
+	webhook.Active = subscription.Active
+	webhook.CreatedAt = subscription.CreatedAt
+	webhook.UpdatedAt = subscription.UpdatedAt
+
+	return &webhook
+}
+
+func WebhookRequestDTOToWebhookSubscription(webhook *dto.WebhookRequestDTO) *model.WebhookSubscription {
+	var subscription model.WebhookSubscription
+
+	subscription.CompanyID = webhook.CompanyID
+	subscription.URL = webhook.URL
+	subscription.EventTypes = append([]string{}, webhook.EventTypes...)
+	subscription.Active = webhook.Active == nil || *webhook.Active
+
+	return &subscription
+}
+
+func WebhookDeliveryToWebhookDeliveryResponseDTO(delivery *model.WebhookDelivery) *dto.WebhookDeliveryResponseDTO {
+	var result dto.WebhookDeliveryResponseDTO
+
+	result.ID = delivery.ID
+	result.EventID = delivery.EventID
+	result.EventType = delivery.EventType
+	result.Status = delivery.Status
+	result.Attempts = delivery.Attempts
+	result.ResponseStatus = delivery.ResponseStatus
+	result.LastError = delivery.LastError
+	if delivery.Status == model.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		result.NextAttemptAt = &nextAttemptAt
+	}
+	result.DeliveredAt = delivery.DeliveredAt
+	result.CreatedAt = delivery.CreatedAt
+
+	return &result
+}
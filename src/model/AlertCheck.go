@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// AlertCheck is an offer waiting to be matched against the saved searches
+// after the event with the given id made it available to candidates. A check
+// that failed is not picked up again before its next attempt is due.
+type AlertCheck struct {
+	ID            int        `json:"id"`
+	JobOfferID    int        `json:"job_offer_id" gorm:"not null"`
+	EventID       string     `json:"event_id" gorm:"not null;unique_index"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	CheckedAt     *time.Time `json:"checked_at" sql:"index"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ApiToken lets an application of a company act on behalf of the company,
+// limited to its scopes. Only the hash of the token is stored and Prefix, the
+// start of the token, helps to tell tokens apart.
+type ApiToken struct {
+	ID         int            `json:"id"`
+	CompanyID  int            `json:"company_id" gorm:"not null" sql:"index"`
+	Name       string         `json:"name" gorm:"not null"`
+	Prefix     string         `json:"prefix" gorm:"not null"`
+	Hash       string         `json:"-" gorm:"not null;unique_index"`
+	Scopes     pq.StringArray `json:"scopes" gorm:"type:text[];not null"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// IsActive tells whether the token is neither revoked nor expired at the time.
+func (token *ApiToken) IsActive(at time.Time) bool {
+	return token.RevokedAt == nil && (token.ExpiresAt == nil || at.Before(*token.ExpiresAt))
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+const (
+	ApplicationStageApplied   = "applied"
+	ApplicationStageScreening = "screening"
+	ApplicationStageInterview = "interview"
+	ApplicationStageOffer     = "offer"
+	ApplicationStageHired     = "hired"
+	ApplicationStageRejected  = "rejected"
+)
+
+// DefaultApplicationStages are the stages of the offers that do not define
+// their own.
+var DefaultApplicationStages = []string{
+	ApplicationStageApplied,
+	ApplicationStageScreening,
+	ApplicationStageInterview,
+	ApplicationStageOffer,
+	ApplicationStageHired,
+	ApplicationStageRejected,
+}
+
+type Application struct {
+	ID          int       `json:"id"`
+	JobOfferID  int       `json:"job_offer_id" gorm:"not null;unique_index:idx_application_candidate"`
+	JobOffer    JobOffer  `json:"-"`
+	CandidateID int       `json:"candidate_id" gorm:"not null;unique_index:idx_application_candidate" sql:"index"`
+	CoverLetter string    `json:"cover_letter" gorm:"type:text"`
+	Stage       string    `json:"stage" gorm:"not null;default:'applied'"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// ApplicationStageChange records a move of an application from one stage to
+// another. The first change of every application has no previous stage.
+type ApplicationStageChange struct {
+	ID            int         `json:"id"`
+	ApplicationID int         `json:"application_id" gorm:"not null" sql:"index"`
+	Application   Application `json:"application"`
+	FromStage     string      `json:"from_stage"`
+	ToStage       string      `json:"to_stage" gorm:"not null"`
+	Note          string      `json:"note" gorm:"type:text"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
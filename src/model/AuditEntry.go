@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+const (
+	AuditActionView          = "view"
+	AuditActionCreate        = "create"
+	AuditActionUpdate        = "update"
+	AuditActionPublish       = "publish"
+	AuditActionClose         = "close"
+	AuditActionDelete        = "delete"
+	AuditActionRestore       = "restore"
+	AuditActionRevert        = "revert"
+	AuditActionListRevisions = "list-revisions"
+	AuditActionTranslate     = "translate"
+	AuditActionListTrash     = "list-trash"
+
+	AuditActionCreateApiToken = "create-api-token"
+	AuditActionListApiTokens  = "list-api-tokens"
+	AuditActionRevokeApiToken = "revoke-api-token"
+
+	AuditActionListApplications = "list-applications"
+	AuditActionChangeStage      = "change-application-stage"
+	AuditActionListStageChanges = "list-application-stage-changes"
+
+	AuditActionCreateWebhook         = "create-webhook"
+	AuditActionUpdateWebhook         = "update-webhook"
+	AuditActionListWebhooks          = "list-webhooks"
+	AuditActionDeleteWebhook         = "delete-webhook"
+	AuditActionListWebhookDeliveries = "list-webhook-deliveries"
+)
+
+// AuditEntry records a request that was rejected because the caller, a user
+// or an API token, may not manage the offers, applications, webhooks or API
+// tokens of the company.
+type AuditEntry struct {
+	ID         int       `json:"id"`
+	UserID     *int      `json:"user_id" sql:"index"`
+	ApiTokenID *int      `json:"api_token_id"`
+	CompanyID  int       `json:"company_id" sql:"index"`
+	JobOfferID *int      `json:"job_offer_id"`
+	Action     string    `json:"action" gorm:"not null"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
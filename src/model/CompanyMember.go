@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// CompanyMember records that a user may manage the job offers of a company.
+type CompanyMember struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id" gorm:"not null;unique_index:idx_company_member"`
+	CompanyID int       `json:"company_id" gorm:"not null;unique_index:idx_company_member"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// JobAlert tells a user that an offer matching one of their saved searches
+// appeared.
+type JobAlert struct {
+	ID            int         `json:"id"`
+	UserID        int         `json:"user_id" gorm:"not null" sql:"index"`
+	SavedSearchID int         `json:"saved_search_id" gorm:"not null;unique_index:idx_job_alert_offer"`
+	SavedSearch   SavedSearch `json:"-"`
+	JobOfferID    int         `json:"job_offer_id" gorm:"not null;unique_index:idx_job_alert_offer"`
+	JobOffer      JobOffer    `json:"-"`
+	DismissedAt   *time.Time  `json:"dismissed_at"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
@@ -1,11 +1,88 @@
 package model
 
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	JobOfferStatusDraft     = "draft"
+	JobOfferStatusPublished = "published"
+	JobOfferStatusClosed    = "closed"
+	JobOfferStatusExpired   = "expired"
+)
+
+const (
+	RemotePolicyOnsite = "onsite"
+	RemotePolicyHybrid = "hybrid"
+	RemotePolicyRemote = "remote"
+)
+
+var RemotePolicies = []string{RemotePolicyOnsite, RemotePolicyHybrid, RemotePolicyRemote}
+
+const (
+	EmploymentTypeFullTime   = "full-time"
+	EmploymentTypePartTime   = "part-time"
+	EmploymentTypeContract   = "contract"
+	EmploymentTypeInternship = "internship"
+)
+
+var EmploymentTypes = []string{EmploymentTypeFullTime, EmploymentTypePartTime, EmploymentTypeContract, EmploymentTypeInternship}
+
+const (
+	SeniorityJunior = "junior"
+	SeniorityMid    = "mid"
+	SenioritySenior = "senior"
+	SeniorityLead   = "lead"
+)
+
+var SeniorityLevels = []string{SeniorityJunior, SeniorityMid, SenioritySenior, SeniorityLead}
+
+const (
+	WorkScheduleFixed    = "fixed"
+	WorkScheduleFlexible = "flexible"
+	WorkScheduleShifts   = "shifts"
+)
+
+var WorkSchedules = []string{WorkScheduleFixed, WorkScheduleFlexible, WorkScheduleShifts}
+
 type JobOffer struct {
-	ID                         int    `json:"id"`
-	CompanyID                  int    `json:"company_id"`
-	Position                   string `json:"position"`
-	JobDescription             string `json:"job_description"`
-	DailyActivitiesDescription string `json:"activities_description"`
-	Skills                     string `json:"skills"`
-	Link                       string `json:"link"`
+	ID                         int            `json:"id"`
+	CompanyID                  int            `json:"company_id"`
+	Position                   string         `json:"position"`
+	JobDescription             string         `json:"job_description"`
+	DailyActivitiesDescription string         `json:"activities_description"`
+	Skills                     []Skill        `json:"skills" gorm:"many2many:job_offer_skills"`
+	SkillsText                 string         `json:"-" gorm:"column:skills"`
+	Link                       string         `json:"link"`
+	Status                     string         `json:"status" gorm:"not null;default:'published'"`
+	ValidFrom                  *time.Time     `json:"valid_from"`
+	ValidUntil                 *time.Time     `json:"valid_until"`
+	Stages                     pq.StringArray `json:"stages" gorm:"type:text[]"`
+	SalaryMin                  *float64       `json:"salary_min"`
+	SalaryMax                  *float64       `json:"salary_max"`
+	Currency                   string         `json:"currency"`
+	SalaryPeriod               string         `json:"salary_period"`
+	City                       string         `json:"city"`
+	Country                    string         `json:"country"`
+	Latitude                   *float64       `json:"latitude"`
+	Longitude                  *float64       `json:"longitude"`
+	RemotePolicy               string         `json:"remote_policy"`
+	EmploymentType             string         `json:"employment_type"`
+	Seniority                  string         `json:"seniority"`
+	WorkSchedule               string         `json:"work_schedule"`
+	UpdatedBy                  string         `json:"updated_by"`
+	CreatedAt                  time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt                  *time.Time     `json:"deleted_at" sql:"index"`
+	Relevance                  float64        `json:"relevance" gorm:"-"`
+}
+
+// ApplicationStages returns the stages the applications to the offer move
+// through, in order. New applications start in the first one.
+func (offer *JobOffer) ApplicationStages() []string {
+	if len(offer.Stages) == 0 {
+		return DefaultApplicationStages
+	}
+	return offer.Stages
 }
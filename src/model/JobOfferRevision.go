@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+const (
+	RevisionActionCreated       = "created"
+	RevisionActionUpdated       = "updated"
+	RevisionActionStatusChanged = "status_changed"
+	RevisionActionDeleted       = "deleted"
+	RevisionActionRestored      = "restored"
+	RevisionActionReverted      = "reverted"
+)
+
+// RevisionChangedBySystem is recorded on the revisions of changes no caller
+// made, such as offers expiring.
+const RevisionChangedBySystem = "system"
+
+type JobOfferRevision struct {
+	ID             int       `json:"id"`
+	JobOfferID     int       `json:"job_offer_id" gorm:"not null;unique_index:idx_job_offer_revision"`
+	Revision       int       `json:"revision" gorm:"not null;unique_index:idx_job_offer_revision"`
+	Action         string    `json:"action" gorm:"not null"`
+	SourceRevision *int      `json:"source_revision"`
+	Snapshot       string    `json:"snapshot" gorm:"type:text;not null"`
+	ChangedBy      string    `json:"changed_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// JobOfferTranslation holds the text fields of an offer in a locale other
+// than the default one.
+type JobOfferTranslation struct {
+	ID                         int            `json:"id"`
+	JobOfferID                 int            `json:"job_offer_id" gorm:"not null;unique_index:idx_job_offer_translation"`
+	Locale                     string         `json:"locale" gorm:"not null;unique_index:idx_job_offer_translation"`
+	Position                   string         `json:"position"`
+	JobDescription             string         `json:"job_description"`
+	DailyActivitiesDescription string         `json:"activities_description"`
+	Skills                     pq.StringArray `json:"skills" gorm:"type:text[]"`
+	SkillsText                 string         `json:"-"`
+	CreatedAt                  time.Time      `json:"created_at"`
+	UpdatedAt                  time.Time      `json:"updated_at"`
+}
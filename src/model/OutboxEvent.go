@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+const (
+	EventJobOfferCreated   = "JobOfferCreated"
+	EventJobOfferUpdated   = "JobOfferUpdated"
+	EventJobOfferPublished = "JobOfferPublished"
+	EventJobOfferClosed    = "JobOfferClosed"
+	EventJobOfferExpired   = "JobOfferExpired"
+	EventJobOfferDeleted   = "JobOfferDeleted"
+	EventJobOfferRestored  = "JobOfferRestored"
+	EventJobOfferReverted  = "JobOfferReverted"
+
+	EventApplicationStageChanged = "ApplicationStageChanged"
+	EventJobAlertCreated         = "JobAlertCreated"
+)
+
+// OutboxEvent is a domain event saved in the same transaction as the change
+// it describes and published afterwards.
+type OutboxEvent struct {
+	ID            int        `json:"id"`
+	EventType     string     `json:"event_type" gorm:"not null"`
+	AggregateID   int        `json:"aggregate_id" gorm:"not null"`
+	CompanyID     int        `json:"company_id"`
+	Revision      int        `json:"revision"`
+	Payload       string     `json:"payload" gorm:"type:text"`
+	OccurredAt    time.Time  `json:"occurred_at" gorm:"not null"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null"`
+	SentAt        *time.Time `json:"sent_at" sql:"index"`
+}
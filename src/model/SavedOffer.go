@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// SavedOffer is an offer a user bookmarked to come back to later.
+type SavedOffer struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id" gorm:"not null;unique_index:idx_saved_offer_user"`
+	JobOfferID int       `json:"job_offer_id" gorm:"not null;unique_index:idx_saved_offer_user"`
+	JobOffer   JobOffer  `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// SavedSearch is a search a user wants to be alerted about. Its filters are
+// the ones of the job offer search, kept as JSON.
+type SavedSearch struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id" gorm:"not null" sql:"index"`
+	Name      string    `json:"name" gorm:"not null"`
+	Query     string    `json:"query"`
+	Filters   string    `json:"filters" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
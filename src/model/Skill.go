@@ -0,0 +1,7 @@
+package model
+
+type Skill struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name" gorm:"unique;not null"`
+	OfferCount int    `json:"offer_count" gorm:"-"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is an event to be posted to a webhook subscription, along
+// with the outcome of the latest attempt to post it.
+type WebhookDelivery struct {
+	ID             int                 `json:"id"`
+	SubscriptionID int                 `json:"subscription_id" gorm:"not null;unique_index:idx_webhook_delivery_event"`
+	Subscription   WebhookSubscription `json:"-"`
+	EventID        string              `json:"event_id" gorm:"not null;unique_index:idx_webhook_delivery_event"`
+	EventType      string              `json:"event_type" gorm:"not null"`
+	Payload        string              `json:"payload" gorm:"type:text;not null"`
+	Status         string              `json:"status" gorm:"not null" sql:"index"`
+	Attempts       int                 `json:"attempts" gorm:"not null;default:0"`
+	ResponseStatus int                 `json:"response_status"`
+	LastError      string              `json:"last_error"`
+	NextAttemptAt  time.Time           `json:"next_attempt_at" gorm:"not null"`
+	DeliveredAt    *time.Time          `json:"delivered_at"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
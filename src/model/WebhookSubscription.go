@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// WebhookSubscription asks for the events of the given types about the offers
+// of a company to be posted to URL, signed with Secret.
+type WebhookSubscription struct {
+	ID         int            `json:"id"`
+	CompanyID  int            `json:"company_id" gorm:"not null" sql:"index"`
+	URL        string         `json:"url" gorm:"not null"`
+	Secret     string         `json:"-" gorm:"not null"`
+	EventTypes pq.StringArray `json:"event_types" gorm:"type:text[];not null"`
+	Active     bool           `json:"active" gorm:"not null;default:true"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+var ErrApiTokenNotFound = errors.New("API token not found")
+
+type IApiTokenRepository interface {
+	Add(model.ApiToken) (model.ApiToken, error)
+	GetById(int) (*model.ApiToken, error)
+	GetByHash(string) (*model.ApiToken, error)
+	GetByCompany(int) ([]*model.ApiToken, error)
+	Revoke(int, time.Time) error
+	Touch(int, time.Time) error
+}
+
+func NewApiTokenRepository(database *gorm.DB) IApiTokenRepository {
+	return &ApiTokenRepository{
+		database,
+	}
+}
+
+type ApiTokenRepository struct {
+	Database *gorm.DB
+}
+
+func (repo *ApiTokenRepository) Add(token model.ApiToken) (model.ApiToken, error) {
+	result := repo.Database.Create(&token)
+
+	return token, result.Error
+}
+
+func (repo *ApiTokenRepository) GetById(id int) (*model.ApiToken, error) {
+	token := model.ApiToken{}
+	if result := repo.Database.Find(&token, "id = ?", id); result.Error != nil {
+		if result.RecordNotFound() {
+			return nil, ErrApiTokenNotFound
+		}
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving API token with id: %d", id))
+	}
+
+	return &token, nil
+}
+
+func (repo *ApiTokenRepository) GetByHash(hash string) (*model.ApiToken, error) {
+	token := model.ApiToken{}
+	if result := repo.Database.Find(&token, "hash = ?", hash); result.Error != nil {
+		if result.RecordNotFound() {
+			return nil, ErrApiTokenNotFound
+		}
+		return nil, errors.New("Error happened during retrieving API token")
+	}
+
+	return &token, nil
+}
+
+// GetByCompany returns every token of the company, including the revoked and
+// expired ones.
+func (repo *ApiTokenRepository) GetByCompany(companyId int) ([]*model.ApiToken, error) {
+	var tokens = []*model.ApiToken{}
+	if result := repo.Database.Where("company_id = ?", companyId).Order("id").Find(&tokens); result.Error != nil {
+		return nil, errors.New("Error happened during retrieving company's API tokens")
+	}
+
+	return tokens, nil
+}
+
+// Revoke marks the token as revoked at the given time, unless it is revoked
+// already.
+func (repo *ApiTokenRepository) Revoke(id int, at time.Time) error {
+	result := repo.Database.Model(&model.ApiToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		UpdateColumn("revoked_at", at)
+	if result.Error != nil {
+		return errors.New(fmt.Sprintf("Error happened during revoking API token with id: %d", id))
+	}
+
+	return nil
+}
+
+// Touch records that the token was used at the given time.
+func (repo *ApiTokenRepository) Touch(id int, at time.Time) error {
+	result := repo.Database.Model(&model.ApiToken{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at)
+	if result.Error != nil {
+		return errors.New(fmt.Sprintf("Error happened during recording use of API token with id: %d", id))
+	}
+
+	return nil
+}
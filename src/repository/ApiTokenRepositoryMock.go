@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ApiTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *ApiTokenRepositoryMock) Add(token model.ApiToken) (model.ApiToken, error) {
+	args := repo.Called(token)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.ApiToken), nil
+	}
+	return args.Get(0).(model.ApiToken), args.Get(1).(error)
+}
+
+func (repo *ApiTokenRepositoryMock) GetById(id int) (*model.ApiToken, error) {
+	args := repo.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*model.ApiToken), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *ApiTokenRepositoryMock) GetByHash(hash string) (*model.ApiToken, error) {
+	args := repo.Called(hash)
+	if args.Get(1) == nil {
+		return args.Get(0).(*model.ApiToken), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *ApiTokenRepositoryMock) GetByCompany(companyId int) ([]*model.ApiToken, error) {
+	args := repo.Called(companyId)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.ApiToken), nil
+	}
+	return args.Get(0).([]*model.ApiToken), args.Get(1).(error)
+}
+
+func (repo *ApiTokenRepositoryMock) Revoke(id int, at time.Time) error {
+	args := repo.Called(id, at)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (repo *ApiTokenRepositoryMock) Touch(id int, at time.Time) error {
+	args := repo.Called(id, at)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+)
+
+var ErrDuplicateApplication = errors.New("Candidate already applied to the job offer")
+var ErrApplicationNotFound = errors.New("Application not found")
+var ErrApplicationStageConflict = errors.New("Application was moved to another stage in the meantime")
+
+// uniqueViolation is the PostgreSQL error code of unique constraint violations.
+const uniqueViolation = "23505"
+
+type IApplicationRepository interface {
+	Add(model.Application) (model.Application, error)
+	GetById(int) (*model.Application, error)
+	ChangeStage(model.ApplicationStageChange, int) (model.ApplicationStageChange, error)
+	GetHistory(int) ([]*model.ApplicationStageChange, error)
+	GetByJobOffer(int) ([]*model.Application, error)
+	GetByCandidate(int) ([]*model.Application, error)
+}
+
+func NewApplicationRepository(database *gorm.DB) IApplicationRepository {
+	return &ApplicationRepository{
+		database,
+	}
+}
+
+type ApplicationRepository struct {
+	Database *gorm.DB
+}
+
+// Add saves the application together with the first entry of its history,
+// placing it in its initial stage.
+func (repo *ApplicationRepository) Add(application model.Application) (model.Application, error) {
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Set("gorm:save_associations", false).Create(&application).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.ApplicationStageChange{ApplicationID: application.ID, ToStage: application.Stage}).Error
+	})
+	if isUniqueViolation(err) {
+		return application, ErrDuplicateApplication
+	}
+
+	return application, err
+}
+
+// GetById returns the application with the offer it applies to, including
+// an offer moved to the trash.
+func (repo *ApplicationRepository) GetById(id int) (*model.Application, error) {
+	application := model.Application{}
+	if result := repo.Database.Preload("JobOffer", unscoped).Find(&application, "id = ?", id); result.Error != nil {
+		if result.RecordNotFound() {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving application with id: %d", id))
+	}
+
+	return &application, nil
+}
+
+// ChangeStage moves the application to the stage of the given change and
+// records the change in its history. The move fails with
+// ErrApplicationStageConflict if the application is no longer in the stage the
+// change moves it from. The event of the change is saved for the company of
+// the offer in the same transaction.
+func (repo *ApplicationRepository) ChangeStage(change model.ApplicationStageChange, companyId int) (model.ApplicationStageChange, error) {
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Application{}).
+			Where("id = ? AND stage = ?", change.ApplicationID, change.FromStage).
+			Updates(map[string]interface{}{"stage": change.ToStage, "updated_at": gorm.NowFunc()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrApplicationStageConflict
+		}
+
+		if err := tx.Set("gorm:save_associations", false).Create(&change).Error; err != nil {
+			return err
+		}
+		change.Application.Stage = change.ToStage
+		return saveApplicationOutboxEvent(tx, &change, companyId)
+	})
+
+	return change, err
+}
+
+func (repo *ApplicationRepository) GetHistory(applicationId int) ([]*model.ApplicationStageChange, error) {
+	var changes = []*model.ApplicationStageChange{}
+	result := repo.Database.
+		Where("application_id = ?", applicationId).
+		Order("created_at, id").
+		Find(&changes)
+	if result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving history of application with id: %d", applicationId))
+	}
+
+	return changes, nil
+}
+
+func (repo *ApplicationRepository) GetByJobOffer(jobOfferId int) ([]*model.Application, error) {
+	var applications = []*model.Application{}
+	result := repo.Database.
+		Where("job_offer_id = ?", jobOfferId).
+		Order("created_at, id").
+		Find(&applications)
+	if result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving applications to job offer with id: %d", jobOfferId))
+	}
+
+	return applications, nil
+}
+
+// GetByCandidate returns the applications of the candidate, newest first, with
+// the offers they apply to, including the ones moved to the trash.
+func (repo *ApplicationRepository) GetByCandidate(candidateId int) ([]*model.Application, error) {
+	var applications = []*model.Application{}
+	result := repo.Database.
+		Preload("JobOffer", unscoped).
+		Preload("JobOffer.Skills", orderSkills).
+		Where("candidate_id = ?", candidateId).
+		Order("created_at DESC, id DESC").
+		Find(&applications)
+	if result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving applications of candidate with id: %d", candidateId))
+	}
+
+	return applications, nil
+}
+
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
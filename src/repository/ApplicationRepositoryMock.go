@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ApplicationRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *ApplicationRepositoryMock) Add(application model.Application) (model.Application, error) {
+	args := repo.Called(application)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.Application), nil
+	}
+	return args.Get(0).(model.Application), args.Get(1).(error)
+}
+
+func (repo *ApplicationRepositoryMock) GetByJobOffer(jobOfferId int) ([]*model.Application, error) {
+	args := repo.Called(jobOfferId)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.Application), nil
+	}
+	return args.Get(0).([]*model.Application), args.Get(1).(error)
+}
+
+func (repo *ApplicationRepositoryMock) GetByCandidate(candidateId int) ([]*model.Application, error) {
+	args := repo.Called(candidateId)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.Application), nil
+	}
+	return args.Get(0).([]*model.Application), args.Get(1).(error)
+}
+
+func (repo *ApplicationRepositoryMock) GetById(id int) (*model.Application, error) {
+	args := repo.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*model.Application), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *ApplicationRepositoryMock) ChangeStage(change model.ApplicationStageChange, companyId int) (model.ApplicationStageChange, error) {
+	args := repo.Called(change, companyId)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.ApplicationStageChange), nil
+	}
+	return args.Get(0).(model.ApplicationStageChange), args.Get(1).(error)
+}
+
+func (repo *ApplicationRepositoryMock) GetHistory(applicationId int) ([]*model.ApplicationStageChange, error) {
+	args := repo.Called(applicationId)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.ApplicationStageChange), nil
+	}
+	return args.Get(0).([]*model.ApplicationStageChange), args.Get(1).(error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"errors"
+	"jobs-ms/src/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type IAuditRepository interface {
+	Add(model.AuditEntry) error
+}
+
+func NewAuditRepository(database *gorm.DB) IAuditRepository {
+	return &AuditRepository{
+		database,
+	}
+}
+
+type AuditRepository struct {
+	Database *gorm.DB
+}
+
+func (repo *AuditRepository) Add(entry model.AuditEntry) error {
+	if err := repo.Database.Create(&entry).Error; err != nil {
+		return errors.New("Error happened during saving audit entry")
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *AuditRepositoryMock) Add(entry model.AuditEntry) error {
+	args := repo.Called(entry)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ICompanyMemberRepository tells which companies users are members of. The
+// default one reads the company_members table.
+type ICompanyMemberRepository interface {
+	IsMember(int, int) (bool, error)
+}
+
+func NewCompanyMemberRepository(database *gorm.DB) ICompanyMemberRepository {
+	return &CompanyMemberRepository{
+		database,
+	}
+}
+
+type CompanyMemberRepository struct {
+	Database *gorm.DB
+}
+
+func (repo *CompanyMemberRepository) IsMember(userId int, companyId int) (bool, error) {
+	var count int
+	err := repo.Database.Model(&model.CompanyMember{}).Where("user_id = ? AND company_id = ?", userId, companyId).Count(&count).Error
+	if err != nil {
+		return false, errors.New(fmt.Sprintf("Error happened during checking membership of user %d in company %d", userId, companyId))
+	}
+
+	return count > 0, nil
+}
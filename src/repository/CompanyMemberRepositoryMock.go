@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type CompanyMemberRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *CompanyMemberRepositoryMock) IsMember(userId int, companyId int) (bool, error) {
+	args := repo.Called(userId, companyId)
+	if args.Get(1) == nil {
+		return args.Bool(0), nil
+	}
+	return false, args.Get(1).(error)
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+	"math"
+
+	"github.com/jinzhu/gorm"
+)
+
+// earthRadiusKm is the mean radius of the earth, used for distances on the
+// pure Go fallback when PostGIS is not installed.
+const earthRadiusKm = 6371.0088
+
+// jobOfferLocationIndex speeds up radius searches when PostGIS is installed.
+const jobOfferLocationIndex = `CREATE INDEX IF NOT EXISTS job_offers_location_idx ON job_offers
+	USING GIST (geography(ST_MakePoint(longitude, latitude)))`
+
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// NearFilter keeps the offers located within RadiusKm kilometers of Point.
+type NearFilter struct {
+	Point    GeoPoint
+	RadiusKm float64
+}
+
+// HasPostGIS tells whether the PostGIS extension is installed in the database.
+func HasPostGIS(database *gorm.DB) bool {
+	var result struct{ Installed bool }
+	err := database.Raw("SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = 'postgis') AS installed").Scan(&result).Error
+	return err == nil && result.Installed
+}
+
+// DistanceKm returns the great-circle distance between two points.
+func DistanceKm(from GeoPoint, to GeoPoint) float64 {
+	fromLatitude, toLatitude := radians(from.Latitude), radians(to.Latitude)
+	latitudeDelta := toLatitude - fromLatitude
+	longitudeDelta := radians(to.Longitude - from.Longitude)
+
+	a := math.Pow(math.Sin(latitudeDelta/2), 2) +
+		math.Cos(fromLatitude)*math.Cos(toLatitude)*math.Pow(math.Sin(longitudeDelta/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// applyNearFilter limits query to the offers near the point of the filter.
+// With PostGIS the distance is computed by the database. Without it query is
+// only limited to a bounding box around the circle, and the returned function
+// tells which of the offers found are inside it.
+func applyNearFilter(query *gorm.DB, near *NearFilter, postGIS bool) (*gorm.DB, func(*model.JobOffer) bool) {
+	if near == nil {
+		return query, nil
+	}
+
+	if postGIS {
+		return query.Where("ST_DWithin(geography(ST_MakePoint(job_offers.longitude, job_offers.latitude)), geography(ST_MakePoint(?, ?)), ?)",
+			near.Point.Longitude, near.Point.Latitude, near.RadiusKm*1000), nil
+	}
+
+	query = query.Where("job_offers.latitude BETWEEN ? AND ?", near.Point.Latitude-latitudeDelta(near), near.Point.Latitude+latitudeDelta(near))
+	if delta, ok := longitudeDelta(near); ok {
+		query = query.Where("job_offers.longitude BETWEEN ? AND ?", near.Point.Longitude-delta, near.Point.Longitude+delta)
+	}
+
+	return query, near.Contains
+}
+
+// Contains tells whether the offer is located inside the circle of the filter.
+func (near *NearFilter) Contains(offer *model.JobOffer) bool {
+	if offer.Latitude == nil || offer.Longitude == nil {
+		return false
+	}
+	return DistanceKm(near.Point, GeoPoint{*offer.Latitude, *offer.Longitude}) <= near.RadiusKm
+}
+
+// latitudeDelta returns how many degrees of latitude the radius spans.
+func latitudeDelta(near *NearFilter) float64 {
+	return near.RadiusKm / earthRadiusKm * 180 / math.Pi
+}
+
+// longitudeDelta returns how many degrees of longitude the radius spans at the
+// latitude of the point, or false when the circle reaches a pole or crosses
+// the antimeridian and longitude cannot be bounded.
+func longitudeDelta(near *NearFilter) (float64, bool) {
+	latitude := latitudeDelta(near)
+	if math.Abs(near.Point.Latitude)+latitude >= 90 {
+		return 0, false
+	}
+
+	delta := latitude / math.Cos(radians(math.Abs(near.Point.Latitude)+latitude))
+	if math.Abs(near.Point.Longitude)+delta > 180 {
+		return 0, false
+	}
+	return delta, true
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
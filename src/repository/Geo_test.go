@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GeoUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestGeoUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(GeoUnitTestsSuite))
+}
+
+func (suite *GeoUnitTestsSuite) TestDistanceKm() {
+	degreeKm := 2 * math.Pi * earthRadiusKm / 360
+	tests := []struct {
+		from     GeoPoint
+		to       GeoPoint
+		expected float64
+	}{
+		{GeoPoint{44.8176, 20.4569}, GeoPoint{44.8176, 20.4569}, 0},
+		{GeoPoint{0, 0}, GeoPoint{0, 1}, degreeKm},
+		{GeoPoint{0, 0}, GeoPoint{1, 0}, degreeKm},
+		{GeoPoint{0, 179.5}, GeoPoint{0, -179.5}, degreeKm},
+		{GeoPoint{90, 0}, GeoPoint{-90, 0}, math.Pi * earthRadiusKm},
+		{GeoPoint{89.5, 0}, GeoPoint{89.5, 180}, degreeKm},
+		{GeoPoint{0, 0}, GeoPoint{0, 180}, math.Pi * earthRadiusKm},
+		{GeoPoint{51.5074, -0.1278}, GeoPoint{48.8566, 2.3522}, 343.9},
+	}
+
+	for _, test := range tests {
+		assert.InDelta(suite.T(), test.expected, DistanceKm(test.from, test.to), 0.5, "from %v to %v", test.from, test.to)
+		assert.InDelta(suite.T(), test.expected, DistanceKm(test.to, test.from), 0.5, "from %v to %v", test.to, test.from)
+	}
+}
+
+func (suite *GeoUnitTestsSuite) TestNearFilter_Contains() {
+	location := func(latitude float64, longitude float64) *model.JobOffer {
+		return &model.JobOffer{Latitude: &latitude, Longitude: &longitude}
+	}
+	tests := []struct {
+		near     NearFilter
+		offer    *model.JobOffer
+		expected bool
+	}{
+		{NearFilter{GeoPoint{44.8176, 20.4569}, 100}, location(45.2671, 19.8335), true},
+		{NearFilter{GeoPoint{44.8176, 20.4569}, 50}, location(45.2671, 19.8335), false},
+		{NearFilter{GeoPoint{44.8176, 20.4569}, 100}, location(45.6, 21.66), false},
+		{NearFilter{GeoPoint{0, 179.9}, 50}, location(0, -179.9), true},
+		{NearFilter{GeoPoint{89.9, 0}, 50}, location(89.9, 180), true},
+		{NearFilter{GeoPoint{0, 0}, 20000}, &model.JobOffer{}, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(suite.T(), test.expected, test.near.Contains(test.offer), "%v", test.near)
+	}
+}
+
+func (suite *GeoUnitTestsSuite) TestLongitudeDelta() {
+	tests := []struct {
+		near     NearFilter
+		expected float64
+		bounded  bool
+	}{
+		{NearFilter{GeoPoint{0, 0}, 111.195}, 1.0002, true},
+		{NearFilter{GeoPoint{60, 0}, 111.195}, 2.0627, true},
+		{NearFilter{GeoPoint{-60, 0}, 111.195}, 2.0627, true},
+		{NearFilter{GeoPoint{89, 0}, 200}, 0, false},
+		{NearFilter{GeoPoint{-89.5, 10}, 100}, 0, false},
+		{NearFilter{GeoPoint{0, 179.9}, 50}, 0, false},
+		{NearFilter{GeoPoint{0, -179.9}, 50}, 0, false},
+	}
+
+	for _, test := range tests {
+		delta, bounded := longitudeDelta(&test.near)
+
+		assert.Equal(suite.T(), test.bounded, bounded, "%v", test.near)
+		assert.InDelta(suite.T(), test.expected, delta, 0.001, "%v", test.near)
+	}
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+var ErrJobAlertNotFound = errors.New("Job alert not found")
+
+type JobAlertPage struct {
+	Alerts  []*model.JobAlert
+	HasMore bool
+	Next    *Cursor
+	Total   *int
+}
+
+type IJobAlertRepository interface {
+	AddCheck(model.AlertCheck) error
+	GetPendingChecks(time.Time, int) ([]*model.AlertCheck, error)
+	CompleteCheck(int, []*model.JobAlert, int, time.Time) (int, error)
+	FailCheck(int, int, string, time.Time) error
+	GetByUser(int, bool, PageRequest) (*JobAlertPage, error)
+	Dismiss(int, int, time.Time) error
+}
+
+func NewJobAlertRepository(database *gorm.DB) IJobAlertRepository {
+	return &JobAlertRepository{
+		database,
+	}
+}
+
+type JobAlertRepository struct {
+	Database *gorm.DB
+}
+
+// AddCheck queues the offer to be matched against the saved searches. An
+// event is queued only once, however many times it is delivered.
+func (repo *JobAlertRepository) AddCheck(check model.AlertCheck) error {
+	err := repo.Database.Set("gorm:insert_option", "ON CONFLICT (event_id) DO NOTHING").Create(&check).Error
+	// Nothing is returned when the event was already queued.
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// GetPendingChecks returns the oldest checks that are not done and are due at
+// the given time.
+func (repo *JobAlertRepository) GetPendingChecks(now time.Time, limit int) ([]*model.AlertCheck, error) {
+	var checks = []*model.AlertCheck{}
+	result := repo.Database.Where("checked_at IS NULL AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", now).
+		Order("id").Limit(limit).Find(&checks)
+	if result.Error != nil {
+		return nil, errors.New("Error happened during retrieving pending alert checks")
+	}
+
+	return checks, nil
+}
+
+// CompleteCheck saves the alerts the check produced, each with its event for
+// the company of the offer, and marks the check as done. Alerts a user was
+// already given are skipped. It returns how many alerts were saved.
+func (repo *JobAlertRepository) CompleteCheck(checkId int, alerts []*model.JobAlert, companyId int, checkedAt time.Time) (int, error) {
+	saved := 0
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		for _, alert := range alerts {
+			err := tx.Set("gorm:insert_option", "ON CONFLICT (saved_search_id, job_offer_id) DO NOTHING").
+				Set("gorm:save_associations", false).
+				Create(alert).Error
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := saveJobAlertOutboxEvent(tx, alert, companyId); err != nil {
+				return err
+			}
+			saved++
+		}
+
+		return tx.Model(&model.AlertCheck{ID: checkId}).Update("checked_at", checkedAt).Error
+	})
+
+	return saved, err
+}
+
+// FailCheck records a failed attempt at the check and when it is to be tried
+// again.
+func (repo *JobAlertRepository) FailCheck(checkId int, attempts int, lastError string, nextAttemptAt time.Time) error {
+	result := repo.Database.Model(&model.AlertCheck{ID: checkId}).Updates(map[string]interface{}{
+		"attempts":        attempts,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	})
+	if result.Error != nil {
+		return errors.New(fmt.Sprintf("Error happened during failing alert check with id: %d", checkId))
+	}
+
+	return nil
+}
+
+// GetByUser returns a page of the alerts of the user, newest first, with the
+// offers they are about.
+func (repo *JobAlertRepository) GetByUser(userId int, includeDismissed bool, page PageRequest) (*JobAlertPage, error) {
+	result := JobAlertPage{Alerts: []*model.JobAlert{}}
+	query := repo.Database.Where("user_id = ?", userId)
+	if !includeDismissed {
+		query = query.Where("dismissed_at IS NULL")
+	}
+
+	if page.WithTotal {
+		var total int
+		if err := query.Model(&model.JobAlert{}).Count(&total).Error; err != nil {
+			return nil, errors.New("Error happened during retrieving user's job alerts")
+		}
+		result.Total = &total
+	}
+
+	err := pageById(query, page).
+		Preload("SavedSearch").
+		Preload("JobOffer", unscoped).
+		Preload("JobOffer.Skills", orderSkills).
+		Find(&result.Alerts).Error
+	if err != nil {
+		return nil, errors.New("Error happened during retrieving user's job alerts")
+	}
+
+	if len(result.Alerts) > page.Limit {
+		result.Alerts = result.Alerts[:page.Limit]
+		result.HasMore = true
+		result.Next = idCursor(page, result.Alerts[page.Limit-1].ID)
+	}
+
+	return &result, nil
+}
+
+// Dismiss hides the alert of the user from the alerts listed by default.
+// Dismissing an alert again keeps the time it was first dismissed at.
+func (repo *JobAlertRepository) Dismiss(userId int, id int, dismissedAt time.Time) error {
+	result := repo.Database.Model(&model.JobAlert{}).
+		Where("id = ? AND user_id = ?", id, userId).
+		Update("dismissed_at", gorm.Expr("COALESCE(dismissed_at, ?)", dismissedAt))
+	if result.Error != nil {
+		return errors.New(fmt.Sprintf("Error happened during dismissing job alert with id: %d", id))
+	}
+	if result.RowsAffected == 0 {
+		return ErrJobAlertNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type JobAlertRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *JobAlertRepositoryMock) AddCheck(check model.AlertCheck) error {
+	args := repo.Called(check)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (repo *JobAlertRepositoryMock) GetPendingChecks(now time.Time, limit int) ([]*model.AlertCheck, error) {
+	args := repo.Called(now, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.AlertCheck), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobAlertRepositoryMock) CompleteCheck(checkId int, alerts []*model.JobAlert, companyId int, checkedAt time.Time) (int, error) {
+	args := repo.Called(checkId, alerts, companyId, checkedAt)
+	if args.Get(1) == nil {
+		return args.Int(0), nil
+	}
+	return args.Int(0), args.Get(1).(error)
+}
+
+func (repo *JobAlertRepositoryMock) FailCheck(checkId int, attempts int, lastError string, nextAttemptAt time.Time) error {
+	args := repo.Called(checkId, attempts, lastError, nextAttemptAt)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (repo *JobAlertRepositoryMock) GetByUser(userId int, includeDismissed bool, page PageRequest) (*JobAlertPage, error) {
+	args := repo.Called(userId, includeDismissed, page)
+	if args.Get(1) == nil {
+		return args.Get(0).(*JobAlertPage), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobAlertRepositoryMock) Dismiss(userId int, id int, dismissedAt time.Time) error {
+	args := repo.Called(userId, id, dismissedAt)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
@@ -1,42 +1,161 @@
 package repository
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"jobs-ms/src/model"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 )
 
+var ErrJobOfferNotFound = errors.New("Job offer not found")
+var ErrJobOfferRevisionNotFound = errors.New("Job offer revision not found")
+var ErrJobOfferStatusConflict = errors.New("Job offer was moved to another status in the meantime")
+
+// statusEvents maps every status an offer can move to to its event.
+var statusEvents = map[string]string{
+	model.JobOfferStatusPublished: model.EventJobOfferPublished,
+	model.JobOfferStatusClosed:    model.EventJobOfferClosed,
+	model.JobOfferStatusExpired:   model.EventJobOfferExpired,
+}
+
 type IJobOfferRepository interface {
 	Add(model.JobOffer) (model.JobOffer, error)
-	GetByCompany(int) ([]*model.JobOffer, error)
-	GetAll() ([]*model.JobOffer, error)
-	Search(string) ([]*model.JobOffer, error)
+	Update(model.JobOffer) (model.JobOffer, error)
+	UpdateStatus(int, string, string, string) (*model.JobOffer, error)
+	GetByCompany(int, SearchFilter, PageRequest) (*JobOfferPage, error)
+	GetAll(SearchFilter, PageRequest) (*JobOfferPage, error)
+	Search(SearchFilter, PageRequest) (*JobOfferPage, error)
+	GetExpired(time.Time) ([]*model.JobOffer, error)
+	GetTrash(int) ([]*model.JobOffer, error)
+	Restore(int, string) (*model.JobOffer, error)
+	Purge(time.Time) (int64, error)
 	GetById(int) (*model.JobOffer, error)
-	Delete(int) error
+	GetCompanyID(int) (int, error)
+	Delete(int, string) error
+	Revert(model.JobOffer, int) (model.JobOffer, error)
+	GetRevisions(int) ([]*model.JobOfferRevision, error)
+	GetRevision(int, int) (*model.JobOfferRevision, error)
+	MatchingSearches(int, map[int]SearchFilter) ([]int, error)
+	SaveTranslation(model.JobOfferTranslation) (model.JobOfferTranslation, error)
+	GetTranslations([]int, string) ([]*model.JobOfferTranslation, error)
 }
 
 func NewJobOfferRepository(database *gorm.DB) IJobOfferRepository {
 	return &JobOfferRepository{
-		database,
+		Database: database,
+		PostGIS:  HasPostGIS(database),
 	}
 }
 
 type JobOfferRepository struct {
 	Database *gorm.DB
+	// PostGIS tells whether radius searches can be computed by the database.
+	PostGIS bool
 }
 
 func (repo *JobOfferRepository) Add(offer model.JobOffer) (model.JobOffer, error) {
-	err := repo.Database.Save(&offer).Error
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		if err := saveJobOffer(tx, &offer); err != nil {
+			return err
+		}
+		jobOfferRevision, err := saveRevision(tx, &offer, model.RevisionActionCreated, nil)
+		if err != nil {
+			return err
+		}
+		return saveOutboxEvent(tx, &offer, jobOfferRevision, model.EventJobOfferCreated)
+	})
 
 	return offer, err
 }
 
-func (repo *JobOfferRepository) GetByCompany(id int) ([]*model.JobOffer, error) {
-	var offers = []*model.JobOffer{}
-	if result := repo.Database.Find(&offers, "company_id = ?", id); result.Error != nil {
+// Update saves the offer, which stays in the status it is in.
+func (repo *JobOfferRepository) Update(offer model.JobOffer) (model.JobOffer, error) {
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		status, err := lockJobOffer(tx, offer.ID)
+		if err != nil {
+			return err
+		}
+		offer.Status = status
+
+		if err := saveJobOffer(tx, &offer); err != nil {
+			return err
+		}
+		jobOfferRevision, err := saveRevision(tx, &offer, model.RevisionActionUpdated, nil)
+		if err != nil {
+			return err
+		}
+		return saveOutboxEvent(tx, &offer, jobOfferRevision, model.EventJobOfferUpdated)
+	})
+
+	return offer, err
+}
+
+// Revert saves the offer restored from the given revision, which stays in the
+// status it is in.
+func (repo *JobOfferRepository) Revert(offer model.JobOffer, revision int) (model.JobOffer, error) {
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		status, err := lockJobOffer(tx, offer.ID)
+		if err != nil {
+			return err
+		}
+		offer.Status = status
+
+		if err := saveJobOffer(tx, &offer); err != nil {
+			return err
+		}
+		jobOfferRevision, err := saveRevision(tx, &offer, model.RevisionActionReverted, &revision)
+		if err != nil {
+			return err
+		}
+		return saveOutboxEvent(tx, &offer, jobOfferRevision, model.EventJobOfferReverted)
+	})
+
+	return offer, err
+}
+
+// UpdateStatus moves the offer from the status fromStatus to the status on
+// behalf of changedBy. The move fails with ErrJobOfferStatusConflict if the
+// offer is no longer in fromStatus or no longer exists.
+func (repo *JobOfferRepository) UpdateStatus(id int, fromStatus string, status string, changedBy string) (*model.JobOffer, error) {
+	var offer *model.JobOffer
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.JobOffer{}).
+			Where("id = ? AND status = ?", id, fromStatus).
+			Updates(map[string]interface{}{"status": status, "updated_by": changedBy})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrJobOfferStatusConflict
+		}
+
+		var err error
+		if offer, err = findJobOffer(tx, id); err != nil {
+			return err
+		}
+		jobOfferRevision, err := saveRevision(tx, offer, model.RevisionActionStatusChanged, nil)
+		if err != nil {
+			return err
+		}
+		return saveOutboxEvent(tx, offer, jobOfferRevision, statusEvents[status])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return offer, nil
+}
+
+// GetByCompany returns the offers of the company that match the filter.
+func (repo *JobOfferRepository) GetByCompany(id int, filter SearchFilter, page PageRequest) (*JobOfferPage, error) {
+	filter.CompanyID = &id
+	offers, err := findJobOfferPage(applySearchFilter(repo.Database, filter), page, nil, nil)
+	if err != nil {
 		return nil, errors.New("Error happened during retrieving company's job offers")
 	}
 
@@ -44,39 +163,343 @@ func (repo *JobOfferRepository) GetByCompany(id int) ([]*model.JobOffer, error)
 }
 
 func (repo *JobOfferRepository) GetById(id int) (*model.JobOffer, error) {
+	return findJobOffer(repo.Database, id)
+}
+
+// GetCompanyID returns the id of the company of the offer, including offers
+// in the trash.
+func (repo *JobOfferRepository) GetCompanyID(id int) (int, error) {
 	offer := model.JobOffer{}
-	if result := repo.Database.Find(&offer, "ID = ?", id); result.Error != nil {
-		return nil, errors.New(fmt.Sprintf("Error happened during retrieving job offer with id: %d", id))
+	if result := repo.Database.Unscoped().Select("company_id").Find(&offer, "id = ?", id); result.Error != nil {
+		if result.RecordNotFound() {
+			return 0, ErrJobOfferNotFound
+		}
+		return 0, errors.New(fmt.Sprintf("Error happened during retrieving company of job offer with id: %d", id))
 	}
 
-	return &offer, nil
+	return offer.CompanyID, nil
 }
 
-func (repo *JobOfferRepository) Delete(id int) error {
-	result := repo.Database.Delete(&model.JobOffer{}, id)
+// Delete moves the offer to the trash on behalf of changedBy, from where it
+// can be restored until it is purged.
+func (repo *JobOfferRepository) Delete(id int, changedBy string) error {
+	return repo.Database.Transaction(func(tx *gorm.DB) error {
+		offer, err := findJobOffer(tx, id)
+		if err != nil {
+			return err
+		}
+
+		now := gorm.NowFunc()
+		if err := tx.Model(&model.JobOffer{ID: id}).Updates(map[string]interface{}{"deleted_at": now, "updated_by": changedBy}).Error; err != nil {
+			return err
+		}
+		offer.DeletedAt = &now
+		offer.UpdatedBy = changedBy
+		jobOfferRevision, err := saveRevision(tx, offer, model.RevisionActionDeleted, nil)
+		if err != nil {
+			return err
+		}
+		return saveOutboxEvent(tx, offer, jobOfferRevision, model.EventJobOfferDeleted)
+	})
+}
 
+func (repo *JobOfferRepository) GetTrash(companyId int) ([]*model.JobOffer, error) {
+	var offers = []*model.JobOffer{}
+	result := repo.Database.Unscoped().
+		Preload("Skills", orderSkills).
+		Where("company_id = ? AND deleted_at IS NOT NULL", companyId).
+		Order("deleted_at DESC").
+		Find(&offers)
 	if result.Error != nil {
+		return nil, errors.New("Error happened during retrieving company's deleted job offers")
+	}
+
+	return offers, nil
+}
+
+// Restore takes the offer out of the trash on behalf of changedBy.
+func (repo *JobOfferRepository) Restore(id int, changedBy string) (*model.JobOffer, error) {
+	var offer *model.JobOffer
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Model(&model.JobOffer{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Updates(map[string]interface{}{"deleted_at": nil, "updated_by": changedBy})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrJobOfferNotFound
+		}
+
+		var err error
+		if offer, err = findJobOffer(tx, id); err != nil {
+			return err
+		}
+		jobOfferRevision, err := saveRevision(tx, offer, model.RevisionActionRestored, nil)
+		if err != nil {
+			return err
+		}
+		return saveOutboxEvent(tx, offer, jobOfferRevision, model.EventJobOfferRestored)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return offer, nil
+}
+
+// Purge permanently removes the offers that were moved to the trash before
+// the given time and returns how many were removed. Applications to the
+// offers are kept for the candidates, and offers users saved stay saved and
+// are flagged as deleted.
+func (repo *JobOfferRepository) Purge(before time.Time) (int64, error) {
+	var purged int64
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM job_offer_skills WHERE job_offer_id IN (SELECT id FROM job_offers WHERE deleted_at < ?)", before).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM job_offer_translations WHERE job_offer_id IN (SELECT id FROM job_offers WHERE deleted_at < ?)", before).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM job_offer_revisions WHERE job_offer_id IN (SELECT id FROM job_offers WHERE deleted_at < ?)", before).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM job_alerts WHERE job_offer_id IN (SELECT id FROM job_offers WHERE deleted_at < ?)", before).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM alert_checks WHERE job_offer_id IN (SELECT id FROM job_offers WHERE deleted_at < ?)", before).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&model.JobOffer{})
+		purged = result.RowsAffected
 		return result.Error
+	})
+
+	return purged, err
+}
+
+func (repo *JobOfferRepository) GetAll(filter SearchFilter, page PageRequest) (*JobOfferPage, error) {
+	query, keep := applyNearFilter(applySearchFilter(repo.Database, filter), filter.Near, repo.PostGIS)
+	offers, err := findJobOfferPage(query, page, nil, keep)
+	if err != nil {
+		return nil, errors.New("Error happened during retrieving company's job offers")
 	}
 
-	return nil
+	return offers, nil
 }
 
-func (repo *JobOfferRepository) GetAll() ([]*model.JobOffer, error) {
-	var offers = []*model.JobOffer{}
-	if result := repo.Database.Find(&offers); result.Error != nil {
+func (repo *JobOfferRepository) Search(filter SearchFilter, page PageRequest) (*JobOfferPage, error) {
+	query, keep := applyNearFilter(applySearchFilter(repo.Database, filter), filter.Near, repo.PostGIS)
+	offers, err := findJobOfferPage(query, page, rankExpression(filter), keep)
+	if err != nil {
 		return nil, errors.New("Error happened during retrieving company's job offers")
 	}
 
 	return offers, nil
 }
 
-func (repo *JobOfferRepository) Search(param string) ([]*model.JobOffer, error) {
-	searchParam := "%" + strings.ToLower(param) + "%"
+// GetExpired returns the published offers whose valid until date is not after now.
+func (repo *JobOfferRepository) GetExpired(now time.Time) ([]*model.JobOffer, error) {
 	var offers = []*model.JobOffer{}
-	if result := repo.Database.Find(&offers, "LOWER(position) LIKE $1 OR LOWER(skills) LIKE $1 OR LOWER(daily_activities_description) LIKE $1 OR LOWER(job_description) LIKE $1", searchParam); result.Error != nil {
-		return nil, errors.New("Error happened during retrieving company's job offers")
+	result := repo.Database.
+		Where("status = ? AND valid_until <= ?", model.JobOfferStatusPublished, now).
+		Order("valid_until").
+		Find(&offers)
+	if result.Error != nil {
+		return nil, errors.New("Error happened during retrieving expired job offers")
 	}
 
 	return offers, nil
 }
+
+func (repo *JobOfferRepository) GetRevisions(offerId int) ([]*model.JobOfferRevision, error) {
+	var revisions = []*model.JobOfferRevision{}
+	if result := repo.Database.Where("job_offer_id = ?", offerId).Order("revision").Find(&revisions); result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving revisions of job offer with id: %d", offerId))
+	}
+
+	return revisions, nil
+}
+
+func (repo *JobOfferRepository) GetRevision(offerId int, revision int) (*model.JobOfferRevision, error) {
+	jobOfferRevision := model.JobOfferRevision{}
+	if result := repo.Database.Find(&jobOfferRevision, "job_offer_id = ? AND revision = ?", offerId, revision); result.Error != nil {
+		if result.RecordNotFound() {
+			return nil, ErrJobOfferRevisionNotFound
+		}
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving revision %d of job offer with id: %d", revision, offerId))
+	}
+
+	return &jobOfferRevision, nil
+}
+
+// MatchingSearches returns the ids of the saved searches whose filters find the
+// offer with the given id, in ascending order. All the filters are evaluated
+// in a single query.
+func (repo *JobOfferRepository) MatchingSearches(id int, filters map[int]SearchFilter) ([]int, error) {
+	searchIds := make([]int, 0, len(filters))
+	for searchId := range filters {
+		searchIds = append(searchIds, searchId)
+	}
+	sort.Ints(searchIds)
+
+	matching := []int{}
+	if len(searchIds) == 0 {
+		return matching, nil
+	}
+
+	queries := make([]string, len(searchIds))
+	subQueries := make([]interface{}, len(searchIds))
+	keeps := map[int]func(*model.JobOffer) bool{}
+	for i, searchId := range searchIds {
+		filter := filters[searchId]
+		query, keep := applyNearFilter(applySearchFilter(repo.Database.Model(&model.JobOffer{}).Where("job_offers.id = ?", id), filter), filter.Near, repo.PostGIS)
+		if keep != nil {
+			keeps[searchId] = keep
+		}
+
+		queries[i] = "?"
+		subQueries[i] = query.Select(fmt.Sprintf("%d AS search_id, job_offers.latitude, job_offers.longitude", searchId)).SubQuery()
+	}
+
+	rows := []struct {
+		SearchID  int
+		Latitude  *float64
+		Longitude *float64
+	}{}
+	if err := repo.Database.Raw(strings.Join(queries, " UNION ALL "), subQueries...).Scan(&rows).Error; err != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during matching job offer with id: %d", id))
+	}
+
+	for _, row := range rows {
+		if keep, ok := keeps[row.SearchID]; ok && !keep(&model.JobOffer{ID: id, Latitude: row.Latitude, Longitude: row.Longitude}) {
+			continue
+		}
+		matching = append(matching, row.SearchID)
+	}
+	sort.Ints(matching)
+
+	return matching, nil
+}
+
+// SaveTranslation creates the translation of the offer to its locale, or
+// replaces the existing one.
+func (repo *JobOfferRepository) SaveTranslation(translation model.JobOfferTranslation) (model.JobOfferTranslation, error) {
+	err := repo.Database.Transaction(func(tx *gorm.DB) error {
+		if _, err := findJobOffer(tx, translation.JobOfferID); err != nil {
+			return err
+		}
+
+		existing := model.JobOfferTranslation{}
+		result := tx.Find(&existing, "job_offer_id = ? AND locale = ?", translation.JobOfferID, translation.Locale)
+		if result.Error != nil && !result.RecordNotFound() {
+			return result.Error
+		}
+		translation.ID = existing.ID
+		translation.CreatedAt = existing.CreatedAt
+		translation.SkillsText = strings.Join(translation.Skills, ", ")
+
+		return tx.Save(&translation).Error
+	})
+
+	return translation, err
+}
+
+// GetTranslations returns the translations of the given offers to the locale.
+// Offers without one are left out.
+func (repo *JobOfferRepository) GetTranslations(offerIds []int, locale string) ([]*model.JobOfferTranslation, error) {
+	var translations = []*model.JobOfferTranslation{}
+	if result := repo.Database.Where("job_offer_id IN (?) AND locale = ?", offerIds, locale).Find(&translations); result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving %s translations of job offers", locale))
+	}
+
+	return translations, nil
+}
+
+func findJobOffer(db *gorm.DB, id int) (*model.JobOffer, error) {
+	offer := model.JobOffer{}
+	if result := db.Preload("Skills", orderSkills).Find(&offer, "ID = ?", id); result.Error != nil {
+		if result.RecordNotFound() {
+			return nil, ErrJobOfferNotFound
+		}
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving job offer with id: %d", id))
+	}
+
+	return &offer, nil
+}
+
+// lockJobOffer locks the row of the offer until the end of the transaction, so
+// that its status can not change in the meantime, and returns the status.
+func lockJobOffer(tx *gorm.DB, id int) (string, error) {
+	offer := model.JobOffer{}
+	if result := tx.Set("gorm:query_option", "FOR UPDATE").Select("id, status").Find(&offer, "id = ?", id); result.Error != nil {
+		if result.RecordNotFound() {
+			return "", ErrJobOfferNotFound
+		}
+		return "", errors.New(fmt.Sprintf("Error happened during locking job offer with id: %d", id))
+	}
+
+	return offer.Status, nil
+}
+
+// saveRevision records the current state of the offer as its next revision,
+// made by whoever last updated the offer. The row of the offer, which can be
+// in the trash, is locked first, so that concurrent changes of the offer are
+// numbered one after the other.
+func saveRevision(tx *gorm.DB, offer *model.JobOffer, action string, sourceRevision *int) (*model.JobOfferRevision, error) {
+	snapshot, err := json.Marshal(offer)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := lockJobOffer(tx.Unscoped(), offer.ID); err != nil {
+		return nil, err
+	}
+
+	var last struct{ Revision int }
+	if err := tx.Table("job_offer_revisions").Select("COALESCE(MAX(revision), 0) AS revision").Where("job_offer_id = ?", offer.ID).Scan(&last).Error; err != nil {
+		return nil, err
+	}
+
+	revision := model.JobOfferRevision{
+		JobOfferID:     offer.ID,
+		Revision:       last.Revision + 1,
+		Action:         action,
+		SourceRevision: sourceRevision,
+		Snapshot:       string(snapshot),
+		ChangedBy:      offer.UpdatedBy,
+	}
+	if err := tx.Create(&revision).Error; err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// saveJobOffer saves the offer together with its skills, creating the skills
+// that do not exist yet.
+func saveJobOffer(tx *gorm.DB, offer *model.JobOffer) error {
+	skills, err := findOrCreateSkills(tx, offer.Skills)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(skills))
+	for i, skill := range skills {
+		names[i] = skill.Name
+	}
+	offer.SkillsText = strings.Join(names, ", ")
+
+	if err := tx.Set("gorm:save_associations", false).Save(offer).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(offer).Association("Skills").Replace(skills).Error; err != nil {
+		return err
+	}
+	offer.Skills = skills
+
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"jobs-ms/src/model"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -18,42 +19,146 @@ func (repo *JobOfferRepositoryMock) Add(offer model.JobOffer) (model.JobOffer, e
 	return args.Get(0).(model.JobOffer), args.Get(1).(error)
 }
 
-func (repo *JobOfferRepositoryMock) GetByCompany(id int) ([]*model.JobOffer, error) {
+func (repo *JobOfferRepositoryMock) Update(offer model.JobOffer) (model.JobOffer, error) {
+	args := repo.Called(offer)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.JobOffer), nil
+	}
+	return args.Get(0).(model.JobOffer), args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) UpdateStatus(id int, fromStatus string, status string, changedBy string) (*model.JobOffer, error) {
+	args := repo.Called(id, fromStatus, status, changedBy)
+	if args.Get(1) == nil {
+		return args.Get(0).(*model.JobOffer), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) GetByCompany(id int, filter SearchFilter, page PageRequest) (*JobOfferPage, error) {
+	args := repo.Called(id, filter, page)
+	if args.Get(1) == nil {
+		return args.Get(0).(*JobOfferPage), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) GetAll(filter SearchFilter, page PageRequest) (*JobOfferPage, error) {
+	args := repo.Called(filter, page)
+	if args.Get(1) == nil {
+		return args.Get(0).(*JobOfferPage), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) Search(filter SearchFilter, page PageRequest) (*JobOfferPage, error) {
+	args := repo.Called(filter, page)
+	if args.Get(1) == nil {
+		return args.Get(0).(*JobOfferPage), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) GetById(id int) (*model.JobOffer, error) {
 	args := repo.Called(id)
 	if args.Get(1) == nil {
-		return args.Get(0).([]*model.JobOffer), nil
+		return args.Get(0).(*model.JobOffer), nil
 	}
-	return args.Get(0).([]*model.JobOffer), args.Get(1).(error)
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) Delete(id int, changedBy string) error {
+	args := repo.Called(id, changedBy)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
 }
 
-func (repo *JobOfferRepositoryMock) GetAll() ([]*model.JobOffer, error) {
-	args := repo.Called()
+func (repo *JobOfferRepositoryMock) GetExpired(now time.Time) ([]*model.JobOffer, error) {
+	args := repo.Called(now)
 	if args.Get(1) == nil {
 		return args.Get(0).([]*model.JobOffer), nil
 	}
 	return args.Get(0).([]*model.JobOffer), args.Get(1).(error)
 }
 
-func (repo *JobOfferRepositoryMock) Search(param string) ([]*model.JobOffer, error) {
-	args := repo.Called(param)
+func (repo *JobOfferRepositoryMock) GetTrash(companyId int) ([]*model.JobOffer, error) {
+	args := repo.Called(companyId)
 	if args.Get(1) == nil {
 		return args.Get(0).([]*model.JobOffer), nil
 	}
 	return args.Get(0).([]*model.JobOffer), args.Get(1).(error)
 }
 
-func (repo *JobOfferRepositoryMock) GetById(id int) (*model.JobOffer, error) {
-	args := repo.Called(id)
+func (repo *JobOfferRepositoryMock) Restore(id int, changedBy string) (*model.JobOffer, error) {
+	args := repo.Called(id, changedBy)
 	if args.Get(1) == nil {
 		return args.Get(0).(*model.JobOffer), nil
 	}
 	return nil, args.Get(1).(error)
 }
 
-func (repo *JobOfferRepositoryMock) Delete(id int) error {
+func (repo *JobOfferRepositoryMock) Purge(before time.Time) (int64, error) {
+	args := repo.Called(before)
+	if args.Get(1) == nil {
+		return args.Get(0).(int64), nil
+	}
+	return args.Get(0).(int64), args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) Revert(offer model.JobOffer, revision int) (model.JobOffer, error) {
+	args := repo.Called(offer, revision)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.JobOffer), nil
+	}
+	return args.Get(0).(model.JobOffer), args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) GetRevisions(offerId int) ([]*model.JobOfferRevision, error) {
+	args := repo.Called(offerId)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.JobOfferRevision), nil
+	}
+	return args.Get(0).([]*model.JobOfferRevision), args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) GetRevision(offerId int, revision int) (*model.JobOfferRevision, error) {
+	args := repo.Called(offerId, revision)
+	if args.Get(1) == nil {
+		return args.Get(0).(*model.JobOfferRevision), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) MatchingSearches(id int, filters map[int]SearchFilter) ([]int, error) {
+	args := repo.Called(id, filters)
+	if args.Get(1) == nil {
+		return args.Get(0).([]int), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) SaveTranslation(translation model.JobOfferTranslation) (model.JobOfferTranslation, error) {
+	args := repo.Called(translation)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.JobOfferTranslation), nil
+	}
+	return model.JobOfferTranslation{}, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) GetTranslations(offerIds []int, locale string) ([]*model.JobOfferTranslation, error) {
+	args := repo.Called(offerIds, locale)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.JobOfferTranslation), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *JobOfferRepositoryMock) GetCompanyID(id int) (int, error) {
 	args := repo.Called(id)
-	if args.Get(0) == nil {
-		return nil
+	if args.Get(1) == nil {
+		return args.Int(0), nil
 	}
-	return args.Get(0).(error)
+	return 0, args.Get(1).(error)
 }
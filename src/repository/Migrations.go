@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+	"jobs-ms/src/utils"
+
+	"github.com/jinzhu/gorm"
+)
+
+// searchVectorColumn indexes the text fields of an offer for full-text search.
+// The weights must match searchFieldWeights.
+const searchVectorColumn = `ALTER TABLE job_offers ADD COLUMN IF NOT EXISTS search_vector tsvector
+	GENERATED ALWAYS AS (
+		setweight(to_tsvector('english', coalesce(position, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(skills, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(job_description, '')), 'C') ||
+		setweight(to_tsvector('english', coalesce(daily_activities_description, '')), 'D')
+	) STORED`
+
+const searchVectorIndex = `CREATE INDEX IF NOT EXISTS job_offers_search_vector_idx ON job_offers USING GIN (search_vector)`
+
+// translationSearchVectorColumn indexes the text fields of translations the
+// same way as searchVectorColumn. Translations can be in any language, so
+// their words are not stemmed.
+const translationSearchVectorColumn = `ALTER TABLE job_offer_translations ADD COLUMN IF NOT EXISTS search_vector tsvector
+	GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(position, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(skills_text, '')), 'B') ||
+		setweight(to_tsvector('simple', coalesce(job_description, '')), 'C') ||
+		setweight(to_tsvector('simple', coalesce(daily_activities_description, '')), 'D')
+	) STORED`
+
+const translationSearchVectorIndex = `CREATE INDEX IF NOT EXISTS job_offer_translations_search_vector_idx ON job_offer_translations USING GIN (search_vector)`
+
+// dropOutboxMessage removes the human readable message outbox events carried
+// before they were published as typed domain events.
+const dropOutboxMessage = `ALTER TABLE outbox_events DROP COLUMN IF EXISTS message`
+
+func Migrate(database *gorm.DB) error {
+	if err := database.AutoMigrate(model.Skill{}, model.JobOffer{}, model.JobOfferRevision{}, model.OutboxEvent{}, model.WebhookSubscription{}, model.WebhookDelivery{}, model.Application{}, model.ApplicationStageChange{}, model.SavedOffer{}, model.SavedSearch{}, model.JobAlert{}, model.AlertCheck{}, model.JobOfferTranslation{}, model.CompanyMember{}, model.AuditEntry{}, model.ApiToken{}).Error; err != nil {
+		return err
+	}
+
+	for _, statement := range []string{searchVectorColumn, searchVectorIndex, translationSearchVectorColumn, translationSearchVectorIndex, dropOutboxMessage} {
+		if err := database.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	if HasPostGIS(database) {
+		if err := database.Exec(jobOfferLocationIndex).Error; err != nil {
+			return err
+		}
+	}
+
+	return migrateSkills(database)
+}
+
+// migrateSkills moves the comma separated skills of offers created before
+// skills were stored separately into the skills table.
+func migrateSkills(database *gorm.DB) error {
+	var offers []*model.JobOffer
+	result := database.
+		Where("skills <> '' AND NOT EXISTS (SELECT 1 FROM job_offer_skills WHERE job_offer_skills.job_offer_id = job_offers.id)").
+		Find(&offers)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	for _, offer := range offers {
+		for _, name := range utils.ParseSkills(offer.SkillsText) {
+			offer.Skills = append(offer.Skills, model.Skill{Name: name})
+		}
+
+		err := database.Transaction(func(tx *gorm.DB) error {
+			return saveJobOffer(tx, offer)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"jobs-ms/src/model"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+type IOutboxRepository interface {
+	GetPending(time.Time, int, int) ([]*model.OutboxEvent, error)
+	MarkSent(int, time.Time) error
+	MarkFailed(int, time.Time, string) error
+}
+
+func NewOutboxRepository(database *gorm.DB) IOutboxRepository {
+	return &OutboxRepository{
+		database,
+	}
+}
+
+type OutboxRepository struct {
+	Database *gorm.DB
+}
+
+// GetPending returns the oldest unsent events that are due at the given time
+// and have been attempted fewer than maxAttempts times.
+func (repo *OutboxRepository) GetPending(now time.Time, maxAttempts int, limit int) ([]*model.OutboxEvent, error) {
+	var events = []*model.OutboxEvent{}
+	result := repo.Database.
+		Where("sent_at IS NULL AND next_attempt_at <= ? AND attempts < ?", now, maxAttempts).
+		Order("id").
+		Limit(limit).
+		Find(&events)
+	if result.Error != nil {
+		return nil, errors.New("Error happened during retrieving pending outbox events")
+	}
+
+	return events, nil
+}
+
+func (repo *OutboxRepository) MarkSent(id int, sentAt time.Time) error {
+	return repo.Database.Model(&model.OutboxEvent{ID: id}).Update("sent_at", sentAt).Error
+}
+
+// MarkFailed records a failed delivery of the event and postpones the next
+// attempt until the given time.
+func (repo *OutboxRepository) MarkFailed(id int, nextAttemptAt time.Time, lastError string) error {
+	return repo.Database.Model(&model.OutboxEvent{ID: id}).Updates(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+	}).Error
+}
+
+// saveOutboxEvent saves the event describing a change of the offer, so it is
+// committed or rolled back together with the change. The event carries the
+// offer as it was saved in the given revision.
+func saveOutboxEvent(tx *gorm.DB, offer *model.JobOffer, revision *model.JobOfferRevision, eventType string) error {
+	now := gorm.NowFunc()
+	return tx.Create(&model.OutboxEvent{
+		EventType:     eventType,
+		AggregateID:   offer.ID,
+		CompanyID:     offer.CompanyID,
+		Revision:      revision.Revision,
+		Payload:       revision.Snapshot,
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}).Error
+}
+
+// saveApplicationOutboxEvent saves the event describing a stage change of an
+// application, carrying the change and the application as it was moved.
+func saveApplicationOutboxEvent(tx *gorm.DB, change *model.ApplicationStageChange, companyId int) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	now := gorm.NowFunc()
+	return tx.Create(&model.OutboxEvent{
+		EventType:     model.EventApplicationStageChanged,
+		AggregateID:   change.ApplicationID,
+		CompanyID:     companyId,
+		Payload:       string(payload),
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}).Error
+}
+
+// saveJobAlertOutboxEvent saves the event telling the user of the alert about
+// the offer that matched their saved search.
+func saveJobAlertOutboxEvent(tx *gorm.DB, alert *model.JobAlert, companyId int) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	now := gorm.NowFunc()
+	return tx.Create(&model.OutboxEvent{
+		EventType:     model.EventJobAlertCreated,
+		AggregateID:   alert.ID,
+		CompanyID:     companyId,
+		Payload:       string(payload),
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}).Error
+}
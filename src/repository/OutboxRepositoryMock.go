@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type OutboxRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *OutboxRepositoryMock) GetPending(now time.Time, maxAttempts int, limit int) ([]*model.OutboxEvent, error) {
+	args := repo.Called(now, maxAttempts, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.OutboxEvent), nil
+	}
+	return args.Get(0).([]*model.OutboxEvent), args.Get(1).(error)
+}
+
+func (repo *OutboxRepositoryMock) MarkSent(id int, sentAt time.Time) error {
+	args := repo.Called(id, sentAt)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (repo *OutboxRepositoryMock) MarkFailed(id int, nextAttemptAt time.Time, lastError string) error {
+	args := repo.Called(id, nextAttemptAt, lastError)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
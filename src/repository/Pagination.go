@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	SortByID        = "id"
+	SortByPosition  = "position"
+	SortByCreatedAt = "created_at"
+	SortByRelevance = "relevance"
+)
+
+var ErrInvalidCursor = errors.New("Invalid cursor")
+
+type PageRequest struct {
+	Limit      int
+	SortField  string
+	Descending bool
+	After      *Cursor
+	WithTotal  bool
+}
+
+// Cursor points at the last row of a page by its sort key value and id, so the
+// next page can continue right after it regardless of inserts and deletes.
+type Cursor struct {
+	SortField  string `json:"s"`
+	Descending bool   `json:"d"`
+	Value      string `json:"v"`
+	ID         int    `json:"i"`
+}
+
+type JobOfferPage struct {
+	Offers  []*model.JobOffer
+	HasMore bool
+	Next    *Cursor
+	Total   *int
+}
+
+func (cursor *Cursor) Encode() string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+func IsSortField(field string) bool {
+	return field == SortByID || field == SortByPosition || field == SortByCreatedAt
+}
+
+func newCursor(page PageRequest, offer *model.JobOffer) *Cursor {
+	cursor := Cursor{SortField: page.SortField, Descending: page.Descending, ID: offer.ID}
+
+	switch page.SortField {
+	case SortByPosition:
+		cursor.Value = offer.Position
+	case SortByCreatedAt:
+		cursor.Value = offer.CreatedAt.Format(time.RFC3339Nano)
+	case SortByRelevance:
+		cursor.Value = strconv.FormatFloat(offer.Relevance, 'g', -1, 64)
+	default:
+		cursor.Value = strconv.Itoa(offer.ID)
+	}
+
+	return &cursor
+}
+
+func cursorValue(cursor *Cursor) (interface{}, error) {
+	switch cursor.SortField {
+	case SortByPosition:
+		return cursor.Value, nil
+	case SortByCreatedAt:
+		value, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return value, nil
+	case SortByRelevance:
+		value, err := strconv.ParseFloat(cursor.Value, 64)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return value, nil
+	default:
+		return cursor.ID, nil
+	}
+}
+
+// expression is a parameterized SQL fragment.
+type expression struct {
+	SQL  string
+	Args []interface{}
+}
+
+// findJobOfferPage runs query for a single page. When rank is given it is
+// selected as the relevance of every offer and can be used as the sort key.
+// When keep is given, only the offers it keeps are part of the page, and
+// further rows are read until the page is full.
+func findJobOfferPage(query *gorm.DB, page PageRequest, rank *expression, keep func(*model.JobOffer) bool) (*JobOfferPage, error) {
+	result := JobOfferPage{Offers: []*model.JobOffer{}}
+
+	if page.WithTotal {
+		total, err := countJobOffers(query, keep)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	direction, comparison := "ASC", ">"
+	if page.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	sort := expression{SQL: "job_offers." + page.SortField}
+	if rank != nil {
+		query = query.Select("job_offers.*, "+rank.SQL+" AS relevance", rank.Args...)
+		if page.SortField == SortByRelevance {
+			sort = *rank
+		}
+	} else if page.SortField == SortByRelevance {
+		return nil, errors.New("Sorting by relevance requires a ranked query")
+	}
+
+	query = query.
+		Order(gorm.Expr(fmt.Sprintf("%s %s, job_offers.id %s", sort.SQL, direction, direction), sort.Args...)).
+		Limit(page.Limit + 1)
+
+	offers, err := collectJobOfferPage(page, keep, func(after *Cursor) ([]*model.JobOffer, error) {
+		batch := query
+		if after != nil {
+			value, err := cursorValue(after)
+			if err != nil {
+				return nil, err
+			}
+			args := append(append([]interface{}{}, sort.Args...), value, after.ID)
+			batch = batch.Where(fmt.Sprintf("(%s, job_offers.id) %s (?, ?)", sort.SQL, comparison), args...)
+		}
+
+		offers := []*model.JobOffer{}
+		err := batch.Preload("Skills", orderSkills).Find(&offers).Error
+		return offers, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Offers = offers
+
+	if len(result.Offers) > page.Limit {
+		result.Offers = result.Offers[:page.Limit]
+		result.HasMore = true
+		result.Next = newCursor(page, result.Offers[page.Limit-1])
+	}
+
+	return &result, nil
+}
+
+// collectJobOfferPage reads the offers of a page with fetch, which returns up
+// to page.Limit + 1 offers following the cursor. Only the offers keep keeps are
+// collected, and batches are read until one offer more than the limit is kept
+// or the offers run out, so callers can tell whether there are more pages.
+func collectJobOfferPage(page PageRequest, keep func(*model.JobOffer) bool, fetch func(*Cursor) ([]*model.JobOffer, error)) ([]*model.JobOffer, error) {
+	kept := []*model.JobOffer{}
+	for after := page.After; ; {
+		offers, err := fetch(after)
+		if err != nil {
+			return nil, err
+		}
+		for _, offer := range offers {
+			if keep == nil || keep(offer) {
+				kept = append(kept, offer)
+			}
+		}
+
+		if keep == nil || len(offers) <= page.Limit || len(kept) > page.Limit {
+			return kept, nil
+		}
+		after = newCursor(page, offers[len(offers)-1])
+	}
+}
+
+// countJobOffers counts the offers found by query that keep keeps. Only the
+// location of the offers is read to be given to keep.
+func countJobOffers(query *gorm.DB, keep func(*model.JobOffer) bool) (int, error) {
+	var total int
+	if keep == nil {
+		err := query.Model(&model.JobOffer{}).Count(&total).Error
+		return total, err
+	}
+
+	offers := []*model.JobOffer{}
+	if err := query.Select("job_offers.id, job_offers.latitude, job_offers.longitude").Find(&offers).Error; err != nil {
+		return 0, err
+	}
+	for _, offer := range offers {
+		if keep(offer) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+// pageById limits query to the page of rows that follow the cursor in the
+// order of their ids. One row more than the limit is selected to tell whether
+// there are more pages.
+func pageById(query *gorm.DB, page PageRequest) *gorm.DB {
+	direction, comparison := "ASC", ">"
+	if page.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if page.After != nil {
+		query = query.Where(fmt.Sprintf("id %s ?", comparison), page.After.ID)
+	}
+
+	return query.Order("id " + direction).Limit(page.Limit + 1)
+}
+
+func idCursor(page PageRequest, id int) *Cursor {
+	return &Cursor{SortField: page.SortField, Descending: page.Descending, Value: strconv.Itoa(id), ID: id}
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PaginationUnitTestsSuite struct {
+	suite.Suite
+	offers []*model.JobOffer
+}
+
+func TestPaginationUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(PaginationUnitTestsSuite))
+}
+
+func (suite *PaginationUnitTestsSuite) SetupTest() {
+	suite.offers = []*model.JobOffer{}
+	for id := 1; id <= 7; id++ {
+		suite.offers = append(suite.offers, &model.JobOffer{ID: id})
+	}
+}
+
+// fetch returns the offers following the cursor in the order of their ids,
+// one more than the limit of the page, and records the cursors it was given.
+func (suite *PaginationUnitTestsSuite) fetch(page PageRequest, cursors *[]*Cursor) func(*Cursor) ([]*model.JobOffer, error) {
+	return func(after *Cursor) ([]*model.JobOffer, error) {
+		*cursors = append(*cursors, after)
+		batch := []*model.JobOffer{}
+		for _, offer := range suite.offers {
+			if (after == nil || offer.ID > after.ID) && len(batch) < page.Limit+1 {
+				batch = append(batch, offer)
+			}
+		}
+		return batch, nil
+	}
+}
+
+func ids(offers []*model.JobOffer) []int {
+	result := []int{}
+	for _, offer := range offers {
+		result = append(result, offer.ID)
+	}
+	return result
+}
+
+func even(offer *model.JobOffer) bool {
+	return offer.ID%2 == 0
+}
+
+func (suite *PaginationUnitTestsSuite) TestCollectJobOfferPage_WithoutKeepReadsOneBatch() {
+	page := PageRequest{Limit: 2, SortField: SortByID}
+	cursors := []*Cursor{}
+
+	offers, err := collectJobOfferPage(page, nil, suite.fetch(page, &cursors))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{1, 2, 3}, ids(offers))
+	assert.Equal(suite.T(), 1, len(cursors))
+}
+
+func (suite *PaginationUnitTestsSuite) TestCollectJobOfferPage_ReadsBatchesUntilPageIsFull() {
+	page := PageRequest{Limit: 2, SortField: SortByID}
+	cursors := []*Cursor{}
+
+	offers, err := collectJobOfferPage(page, even, suite.fetch(page, &cursors))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{2, 4, 6}, ids(offers))
+	assert.Equal(suite.T(), 2, len(cursors))
+	assert.Nil(suite.T(), cursors[0])
+	assert.Equal(suite.T(), 3, cursors[1].ID)
+}
+
+func (suite *PaginationUnitTestsSuite) TestCollectJobOfferPage_StopsWhenOffersRunOut() {
+	page := PageRequest{Limit: 2, SortField: SortByID, After: idCursor(PageRequest{SortField: SortByID}, 4)}
+	cursors := []*Cursor{}
+
+	offers, err := collectJobOfferPage(page, even, suite.fetch(page, &cursors))
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []int{6}, ids(offers))
+	assert.Equal(suite.T(), 2, len(cursors))
+	assert.Equal(suite.T(), 7, cursors[1].ID)
+}
+
+func (suite *PaginationUnitTestsSuite) TestCollectJobOfferPage_KeepsNothing() {
+	page := PageRequest{Limit: 2, SortField: SortByID}
+	cursors := []*Cursor{}
+
+	offers, err := collectJobOfferPage(page, func(*model.JobOffer) bool { return false }, suite.fetch(page, &cursors))
+
+	assert.Nil(suite.T(), err)
+	assert.Empty(suite.T(), offers)
+	assert.Equal(suite.T(), 3, len(cursors))
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+var ErrSavedOfferNotFound = errors.New("Saved job offer not found")
+
+type SavedOfferPage struct {
+	SavedOffers []*model.SavedOffer
+	HasMore     bool
+	Next        *Cursor
+	Total       *int
+}
+
+type ISavedOfferRepository interface {
+	Add(model.SavedOffer) (*model.SavedOffer, error)
+	GetByUser(int, PageRequest) (*SavedOfferPage, error)
+	Delete(int, int) error
+}
+
+func NewSavedOfferRepository(database *gorm.DB) ISavedOfferRepository {
+	return &SavedOfferRepository{
+		database,
+	}
+}
+
+type SavedOfferRepository struct {
+	Database *gorm.DB
+}
+
+// Add saves the offer for the user. Saving an offer again keeps the time it
+// was first saved at.
+func (repo *SavedOfferRepository) Add(savedOffer model.SavedOffer) (*model.SavedOffer, error) {
+	err := repo.Database.Set("gorm:insert_option", "ON CONFLICT (user_id, job_offer_id) DO NOTHING").
+		Set("gorm:save_associations", false).
+		Create(&savedOffer).Error
+	// Nothing is returned when the offer was already saved.
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	saved := model.SavedOffer{}
+	result := repo.Database.
+		Preload("JobOffer", unscoped).
+		Preload("JobOffer.Skills", orderSkills).
+		Find(&saved, "user_id = ? AND job_offer_id = ?", savedOffer.UserID, savedOffer.JobOfferID)
+	if result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving saved job offer with id: %d", savedOffer.JobOfferID))
+	}
+
+	return &saved, nil
+}
+
+// GetByUser returns a page of the offers saved by the user, with the offers
+// that were closed or moved to the trash since.
+func (repo *SavedOfferRepository) GetByUser(userId int, page PageRequest) (*SavedOfferPage, error) {
+	result := SavedOfferPage{SavedOffers: []*model.SavedOffer{}}
+	query := repo.Database.Where("user_id = ?", userId)
+
+	if page.WithTotal {
+		var total int
+		if err := query.Model(&model.SavedOffer{}).Count(&total).Error; err != nil {
+			return nil, errors.New("Error happened during retrieving user's saved job offers")
+		}
+		result.Total = &total
+	}
+
+	err := pageById(query, page).
+		Preload("JobOffer", unscoped).
+		Preload("JobOffer.Skills", orderSkills).
+		Find(&result.SavedOffers).Error
+	if err != nil {
+		return nil, errors.New("Error happened during retrieving user's saved job offers")
+	}
+
+	if len(result.SavedOffers) > page.Limit {
+		result.SavedOffers = result.SavedOffers[:page.Limit]
+		result.HasMore = true
+		result.Next = idCursor(page, result.SavedOffers[page.Limit-1].ID)
+	}
+
+	return &result, nil
+}
+
+func (repo *SavedOfferRepository) Delete(userId int, jobOfferId int) error {
+	result := repo.Database.Where("user_id = ? AND job_offer_id = ?", userId, jobOfferId).Delete(&model.SavedOffer{})
+	if result.Error != nil {
+		return errors.New(fmt.Sprintf("Error happened during deleting saved job offer with id: %d", jobOfferId))
+	}
+	if result.RowsAffected == 0 {
+		return ErrSavedOfferNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SavedOfferRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *SavedOfferRepositoryMock) Add(savedOffer model.SavedOffer) (*model.SavedOffer, error) {
+	args := repo.Called(savedOffer)
+	if args.Get(1) == nil {
+		return args.Get(0).(*model.SavedOffer), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *SavedOfferRepositoryMock) GetByUser(userId int, page PageRequest) (*SavedOfferPage, error) {
+	args := repo.Called(userId, page)
+	if args.Get(1) == nil {
+		return args.Get(0).(*SavedOfferPage), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *SavedOfferRepositoryMock) Delete(userId int, jobOfferId int) error {
+	args := repo.Called(userId, jobOfferId)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+var ErrSavedSearchNotFound = errors.New("Saved search not found")
+
+type ISavedSearchRepository interface {
+	Add(model.SavedSearch) (model.SavedSearch, error)
+	GetByUser(int) ([]*model.SavedSearch, error)
+	GetBatch(int, int) ([]*model.SavedSearch, error)
+	Delete(int, int) error
+}
+
+func NewSavedSearchRepository(database *gorm.DB) ISavedSearchRepository {
+	return &SavedSearchRepository{
+		database,
+	}
+}
+
+type SavedSearchRepository struct {
+	Database *gorm.DB
+}
+
+func (repo *SavedSearchRepository) Add(search model.SavedSearch) (model.SavedSearch, error) {
+	err := repo.Database.Create(&search).Error
+	return search, err
+}
+
+func (repo *SavedSearchRepository) GetByUser(userId int) ([]*model.SavedSearch, error) {
+	var searches = []*model.SavedSearch{}
+	if result := repo.Database.Where("user_id = ?", userId).Order("id").Find(&searches); result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving saved searches of user with id: %d", userId))
+	}
+
+	return searches, nil
+}
+
+// GetBatch returns at most limit saved searches of all users that follow the
+// one with the given id.
+func (repo *SavedSearchRepository) GetBatch(afterId int, limit int) ([]*model.SavedSearch, error) {
+	var searches = []*model.SavedSearch{}
+	if result := repo.Database.Where("id > ?", afterId).Order("id").Limit(limit).Find(&searches); result.Error != nil {
+		return nil, errors.New("Error happened during retrieving saved searches")
+	}
+
+	return searches, nil
+}
+
+// Delete removes the saved search of the user together with its alerts.
+func (repo *SavedSearchRepository) Delete(userId int, id int) error {
+	return repo.Database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("saved_search_id = ? AND user_id = ?", id, userId).Delete(&model.JobAlert{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ? AND user_id = ?", id, userId).Delete(&model.SavedSearch{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSavedSearchNotFound
+		}
+		return nil
+	})
+}
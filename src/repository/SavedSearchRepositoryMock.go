@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SavedSearchRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *SavedSearchRepositoryMock) Add(search model.SavedSearch) (model.SavedSearch, error) {
+	args := repo.Called(search)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.SavedSearch), nil
+	}
+	return args.Get(0).(model.SavedSearch), args.Get(1).(error)
+}
+
+func (repo *SavedSearchRepositoryMock) GetByUser(userId int) ([]*model.SavedSearch, error) {
+	args := repo.Called(userId)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.SavedSearch), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *SavedSearchRepositoryMock) GetBatch(afterId int, limit int) ([]*model.SavedSearch, error) {
+	args := repo.Called(afterId, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.SavedSearch), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *SavedSearchRepositoryMock) Delete(userId int, id int) error {
+	args := repo.Called(userId, id)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"fmt"
+	"jobs-ms/src/utils"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+)
+
+const (
+	SearchFieldPosition       = "position"
+	SearchFieldJobDescription = "job_description"
+	SearchFieldActivities     = "activities_description"
+	SearchFieldSkills         = "skills"
+)
+
+// translatedColumn selects a column of the translation of an offer to a locale.
+const translatedColumn = "(SELECT t.%s FROM job_offer_translations t WHERE t.job_offer_id = job_offers.id AND t.locale = ?)"
+
+// searchFieldWeights maps every searchable field to the weight it is given
+// in the search_vector column.
+var searchFieldWeights = map[string]string{
+	SearchFieldPosition:       "a",
+	SearchFieldSkills:         "b",
+	SearchFieldJobDescription: "c",
+	SearchFieldActivities:     "d",
+}
+
+type SearchFilter struct {
+	Statuses []string
+	// IncludeExpired keeps offers whose valid until date has passed.
+	IncludeExpired bool
+	CompanyID      *int
+	// ExcludedCompanyIDs leaves out the offers of the given companies.
+	ExcludedCompanyIDs []int
+	// SalaryAtLeast is the lowest yearly salary in utils.BaseCurrency the
+	// highest salary of an offer can be. Offers without a salary or in a
+	// currency without a rate are left out.
+	SalaryAtLeast *float64
+	// Near keeps the offers located within a radius of a point. It is not
+	// applied by applySearchFilter, see applyNearFilter.
+	Near            *NearFilter
+	Country         string
+	RemotePolicies  []string
+	EmploymentTypes []string
+	SeniorityLevels []string
+	WorkSchedules   []string
+	Position        string
+	// Skills are matched exactly against normalized skill names.
+	Skills         []string
+	MatchAllSkills bool
+	// RankBySkills ranks the offers by how many of the skills they require
+	// when there is no free text to rank them by.
+	RankBySkills bool
+	Text         string
+	TextFields   []string
+	// Locale searches the position and the free text in the translations of
+	// offers to the locale, for offers that have one. It is empty for the
+	// default locale.
+	Locale string
+}
+
+func IsSearchField(field string) bool {
+	_, ok := searchFieldWeights[field]
+	return ok
+}
+
+func applySearchFilter(query *gorm.DB, filter SearchFilter) *gorm.DB {
+	if len(filter.Statuses) > 0 {
+		query = query.Where("job_offers.status IN (?)", filter.Statuses)
+	}
+
+	if !filter.IncludeExpired {
+		query = query.Where("job_offers.valid_until IS NULL OR job_offers.valid_until > CURRENT_TIMESTAMP")
+	}
+
+	if filter.CompanyID != nil {
+		query = query.Where("job_offers.company_id = ?", *filter.CompanyID)
+	}
+
+	if len(filter.ExcludedCompanyIDs) > 0 {
+		query = query.Where("job_offers.company_id NOT IN (?)", filter.ExcludedCompanyIDs)
+	}
+
+	if filter.SalaryAtLeast != nil {
+		salary := yearlyBaseSalary()
+		query = query.Where(salary.SQL+" >= ?", append(salary.Args, *filter.SalaryAtLeast)...)
+	}
+
+	if filter.Country != "" {
+		query = query.Where("job_offers.country = ?", filter.Country)
+	}
+
+	if len(filter.RemotePolicies) > 0 {
+		query = query.Where("job_offers.remote_policy IN (?)", filter.RemotePolicies)
+	}
+
+	if len(filter.EmploymentTypes) > 0 {
+		query = query.Where("job_offers.employment_type IN (?)", filter.EmploymentTypes)
+	}
+
+	if len(filter.SeniorityLevels) > 0 {
+		query = query.Where("job_offers.seniority IN (?)", filter.SeniorityLevels)
+	}
+
+	if len(filter.WorkSchedules) > 0 {
+		query = query.Where("job_offers.work_schedule IN (?)", filter.WorkSchedules)
+	}
+
+	if filter.Position != "" {
+		if filter.Locale != "" {
+			query = query.Where(fmt.Sprintf("LOWER(COALESCE(%s, job_offers.position)) LIKE ?", fmt.Sprintf(translatedColumn, "position")), filter.Locale, likePattern(filter.Position))
+		} else {
+			query = query.Where("LOWER(job_offers.position) LIKE ?", likePattern(filter.Position))
+		}
+	}
+
+	if len(filter.Skills) > 0 {
+		matched := matchedSkills(filter)
+		if filter.MatchAllSkills {
+			query = query.Where(matched.SQL+" = ?", append(matched.Args, len(filter.Skills))...)
+		} else {
+			query = query.Where(matched.SQL+" > 0", matched.Args...)
+		}
+	}
+
+	if filter.Text != "" {
+		match := textExpression(filter, "%s @@ %s", false)
+		query = query.Where(match.SQL, match.Args...)
+
+		if len(filter.TextFields) > 0 {
+			match = textExpression(filter, "%s @@ %s", true)
+			query = query.Where(match.SQL, match.Args...)
+		}
+	}
+
+	return query
+}
+
+// rankExpression ranks offers by how well their text fields match the free
+// text of the filter, or by how many of its skills they require when asked
+// to, and returns nil when there is nothing to rank by.
+func rankExpression(filter SearchFilter) *expression {
+	if filter.Text == "" {
+		if filter.RankBySkills && len(filter.Skills) > 0 {
+			matched := matchedSkills(filter)
+			return &matched
+		}
+		return nil
+	}
+
+	rank := textExpression(filter, "ts_rank(%s, %s)", true)
+	return &rank
+}
+
+// matchedSkills counts the skills of the filter an offer requires.
+func matchedSkills(filter SearchFilter) expression {
+	return expression{
+		SQL: `(SELECT COUNT(DISTINCT skills.id) FROM job_offer_skills
+			JOIN skills ON skills.id = job_offer_skills.skill_id
+			WHERE job_offer_skills.job_offer_id = job_offers.id AND skills.name = ANY(?))`,
+		Args: []interface{}{pq.Array(filter.Skills)},
+	}
+}
+
+// textExpression formats the search vector of an offer and the query of the
+// free text of the filter into format. When weighted is set and the filter
+// names text fields, only those fields of the vector are used. Offers
+// translated to the locale of the filter are searched in the translation,
+// and the others in their default locale.
+func textExpression(filter SearchFilter, format string, weighted bool) expression {
+	vector := func(column string) string {
+		if weighted && len(filter.TextFields) > 0 {
+			return fmt.Sprintf("ts_filter(%s, '%s')", column, textFieldWeights(filter))
+		}
+		return column
+	}
+
+	text := expression{
+		SQL:  fmt.Sprintf(format, vector("job_offers.search_vector"), "websearch_to_tsquery('english', ?)"),
+		Args: []interface{}{filter.Text},
+	}
+	if filter.Locale == "" {
+		return text
+	}
+
+	translated := fmt.Sprintf(format, vector(fmt.Sprintf(translatedColumn, "search_vector")), "websearch_to_tsquery('simple', ?)")
+	return expression{
+		SQL:  fmt.Sprintf("COALESCE(%s, %s)", translated, text.SQL),
+		Args: []interface{}{filter.Locale, filter.Text, filter.Text},
+	}
+}
+
+// yearlyBaseSalary converts the highest salary of an offer to a yearly one in
+// utils.BaseCurrency, using the same rates and periods as
+// utils.ToYearlyBaseSalary.
+func yearlyBaseSalary() expression {
+	currencies := make([]string, 0, len(utils.CurrencyRates))
+	for currency := range utils.CurrencyRates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	rate := expression{SQL: "CASE job_offers.currency"}
+	for _, currency := range currencies {
+		rate.SQL += " WHEN ? THEN ?"
+		rate.Args = append(rate.Args, currency, utils.CurrencyRates[currency])
+	}
+	rate.SQL += " END"
+
+	periods := expression{SQL: "CASE job_offers.salary_period"}
+	for _, period := range []string{utils.SalaryPeriodHourly, utils.SalaryPeriodMonthly, utils.SalaryPeriodYearly} {
+		periods.SQL += " WHEN ? THEN ?"
+		periods.Args = append(periods.Args, period, utils.SalaryPeriodsPerYear[period])
+	}
+	periods.SQL += " END"
+
+	return expression{
+		SQL:  fmt.Sprintf("COALESCE(job_offers.salary_max, job_offers.salary_min) * (%s) * (%s)", rate.SQL, periods.SQL),
+		Args: append(rate.Args, periods.Args...),
+	}
+}
+
+func textFieldWeights(filter SearchFilter) string {
+	weights := make([]string, len(filter.TextFields))
+	for i, field := range filter.TextFields {
+		weights[i] = searchFieldWeights[field]
+	}
+	return "{" + strings.Join(weights, ",") + "}"
+}
+
+func likePattern(value string) string {
+	return "%" + strings.ToLower(value) + "%"
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+	"jobs-ms/src/model"
+
+	"github.com/jinzhu/gorm"
+)
+
+type ISkillRepository interface {
+	GetAll() ([]*model.Skill, error)
+}
+
+func NewSkillRepository(database *gorm.DB) ISkillRepository {
+	return &SkillRepository{
+		database,
+	}
+}
+
+type SkillRepository struct {
+	Database *gorm.DB
+}
+
+func (repo *SkillRepository) GetAll() ([]*model.Skill, error) {
+	var skills = []*model.Skill{}
+	result := repo.Database.
+		Select("skills.id, skills.name, COUNT(job_offers.id) AS offer_count").
+		Joins("LEFT JOIN job_offer_skills ON job_offer_skills.skill_id = skills.id").
+		Joins("LEFT JOIN job_offers ON job_offers.id = job_offer_skills.job_offer_id AND job_offers.deleted_at IS NULL").
+		Group("skills.id, skills.name").
+		Order("skills.name").
+		Find(&skills)
+	if result.Error != nil {
+		return nil, errors.New("Error happened during retrieving skills")
+	}
+
+	return skills, nil
+}
+
+func orderSkills(db *gorm.DB) *gorm.DB {
+	return db.Order("skills.name")
+}
+
+// findOrCreateSkills returns the stored skills with the given names, creating
+// the missing ones.
+func findOrCreateSkills(tx *gorm.DB, skills []model.Skill) ([]model.Skill, error) {
+	stored := make([]model.Skill, len(skills))
+	for i, skill := range skills {
+		if err := tx.Exec("INSERT INTO skills (name) VALUES (?) ON CONFLICT (name) DO NOTHING", skill.Name).Error; err != nil {
+			return nil, err
+		}
+		if err := tx.Where("name = ?", skill.Name).First(&stored[i]).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return stored, nil
+}
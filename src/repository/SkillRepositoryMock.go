@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SkillRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *SkillRepositoryMock) GetAll() ([]*model.Skill, error) {
+	args := repo.Called()
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.Skill), nil
+	}
+	return args.Get(0).([]*model.Skill), args.Get(1).(error)
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+var ErrWebhookNotFound = errors.New("Webhook subscription not found")
+
+type IWebhookRepository interface {
+	Add(model.WebhookSubscription) (model.WebhookSubscription, error)
+	Update(model.WebhookSubscription) (model.WebhookSubscription, error)
+	GetById(int) (*model.WebhookSubscription, error)
+	GetByCompany(int) ([]*model.WebhookSubscription, error)
+	GetSubscribers(int, string) ([]*model.WebhookSubscription, error)
+	Delete(int) error
+	AddDeliveries([]*model.WebhookDelivery) error
+	GetDeliveries(int, string, int) ([]*model.WebhookDelivery, error)
+	GetPendingDeliveries(time.Time, int) ([]*model.WebhookDelivery, error)
+	UpdateDelivery(*model.WebhookDelivery) error
+}
+
+func NewWebhookRepository(database *gorm.DB) IWebhookRepository {
+	return &WebhookRepository{
+		database,
+	}
+}
+
+type WebhookRepository struct {
+	Database *gorm.DB
+}
+
+func (repo *WebhookRepository) Add(subscription model.WebhookSubscription) (model.WebhookSubscription, error) {
+	result := repo.Database.Create(&subscription)
+
+	return subscription, result.Error
+}
+
+func (repo *WebhookRepository) Update(subscription model.WebhookSubscription) (model.WebhookSubscription, error) {
+	result := repo.Database.Save(&subscription)
+
+	return subscription, result.Error
+}
+
+func (repo *WebhookRepository) GetById(id int) (*model.WebhookSubscription, error) {
+	subscription := model.WebhookSubscription{}
+	if result := repo.Database.Find(&subscription, "id = ?", id); result.Error != nil {
+		if result.RecordNotFound() {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving webhook subscription with id: %d", id))
+	}
+
+	return &subscription, nil
+}
+
+func (repo *WebhookRepository) GetByCompany(companyId int) ([]*model.WebhookSubscription, error) {
+	var subscriptions = []*model.WebhookSubscription{}
+	if result := repo.Database.Where("company_id = ?", companyId).Order("id").Find(&subscriptions); result.Error != nil {
+		return nil, errors.New("Error happened during retrieving company's webhook subscriptions")
+	}
+
+	return subscriptions, nil
+}
+
+// GetSubscribers returns the active subscriptions of the company to the given
+// event type.
+func (repo *WebhookRepository) GetSubscribers(companyId int, eventType string) ([]*model.WebhookSubscription, error) {
+	var subscriptions = []*model.WebhookSubscription{}
+	result := repo.Database.
+		Where("company_id = ? AND active AND ? = ANY(event_types)", companyId, eventType).
+		Order("id").
+		Find(&subscriptions)
+	if result.Error != nil {
+		return nil, errors.New("Error happened during retrieving webhook subscribers")
+	}
+
+	return subscriptions, nil
+}
+
+// Delete removes the subscription together with its delivery log.
+func (repo *WebhookRepository) Delete(id int) error {
+	return repo.Database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&model.WebhookSubscription{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrWebhookNotFound
+		}
+		return nil
+	})
+}
+
+// AddDeliveries saves the deliveries, skipping the ones already saved for the
+// same subscription and event, so an event published twice is posted once.
+func (repo *WebhookRepository) AddDeliveries(deliveries []*model.WebhookDelivery) error {
+	return repo.Database.Transaction(func(tx *gorm.DB) error {
+		for _, delivery := range deliveries {
+			err := tx.Set("gorm:insert_option", "ON CONFLICT (subscription_id, event_id) DO NOTHING").
+				Set("gorm:save_associations", false).
+				Create(delivery).Error
+			// Nothing is returned when the delivery was already saved.
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetDeliveries returns the latest deliveries of the subscription, optionally
+// only the ones with the given status.
+func (repo *WebhookRepository) GetDeliveries(subscriptionId int, status string, limit int) ([]*model.WebhookDelivery, error) {
+	var deliveries = []*model.WebhookDelivery{}
+	query := repo.Database.Where("subscription_id = ?", subscriptionId)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if result := query.Order("id DESC").Limit(limit).Find(&deliveries); result.Error != nil {
+		return nil, errors.New(fmt.Sprintf("Error happened during retrieving deliveries of webhook subscription with id: %d", subscriptionId))
+	}
+
+	return deliveries, nil
+}
+
+// GetPendingDeliveries returns the oldest pending deliveries that are due at
+// the given time, with their subscriptions.
+func (repo *WebhookRepository) GetPendingDeliveries(now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	var deliveries = []*model.WebhookDelivery{}
+	result := repo.Database.
+		Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryPending, now).
+		Order("id").
+		Limit(limit).
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, errors.New("Error happened during retrieving pending webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+func (repo *WebhookRepository) UpdateDelivery(delivery *model.WebhookDelivery) error {
+	return repo.Database.Model(delivery).Updates(map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"response_status": delivery.ResponseStatus,
+		"last_error":      delivery.LastError,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"delivered_at":    delivery.DeliveredAt,
+	}).Error
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"jobs-ms/src/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookRepositoryMock struct {
+	mock.Mock
+}
+
+func (repo *WebhookRepositoryMock) Add(subscription model.WebhookSubscription) (model.WebhookSubscription, error) {
+	args := repo.Called(subscription)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.WebhookSubscription), nil
+	}
+	return args.Get(0).(model.WebhookSubscription), args.Get(1).(error)
+}
+
+func (repo *WebhookRepositoryMock) Update(subscription model.WebhookSubscription) (model.WebhookSubscription, error) {
+	args := repo.Called(subscription)
+	if args.Get(1) == nil {
+		return args.Get(0).(model.WebhookSubscription), nil
+	}
+	return args.Get(0).(model.WebhookSubscription), args.Get(1).(error)
+}
+
+func (repo *WebhookRepositoryMock) GetById(id int) (*model.WebhookSubscription, error) {
+	args := repo.Called(id)
+	if args.Get(1) == nil {
+		return args.Get(0).(*model.WebhookSubscription), nil
+	}
+	return nil, args.Get(1).(error)
+}
+
+func (repo *WebhookRepositoryMock) GetByCompany(companyId int) ([]*model.WebhookSubscription, error) {
+	args := repo.Called(companyId)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.WebhookSubscription), nil
+	}
+	return args.Get(0).([]*model.WebhookSubscription), args.Get(1).(error)
+}
+
+func (repo *WebhookRepositoryMock) GetSubscribers(companyId int, eventType string) ([]*model.WebhookSubscription, error) {
+	args := repo.Called(companyId, eventType)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.WebhookSubscription), nil
+	}
+	return args.Get(0).([]*model.WebhookSubscription), args.Get(1).(error)
+}
+
+func (repo *WebhookRepositoryMock) Delete(id int) error {
+	args := repo.Called(id)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (repo *WebhookRepositoryMock) AddDeliveries(deliveries []*model.WebhookDelivery) error {
+	args := repo.Called(deliveries)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
+
+func (repo *WebhookRepositoryMock) GetDeliveries(subscriptionId int, status string, limit int) ([]*model.WebhookDelivery, error) {
+	args := repo.Called(subscriptionId, status, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.WebhookDelivery), nil
+	}
+	return args.Get(0).([]*model.WebhookDelivery), args.Get(1).(error)
+}
+
+func (repo *WebhookRepositoryMock) GetPendingDeliveries(now time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	args := repo.Called(now, limit)
+	if args.Get(1) == nil {
+		return args.Get(0).([]*model.WebhookDelivery), nil
+	}
+	return args.Get(0).([]*model.WebhookDelivery), args.Get(1).(error)
+}
+
+func (repo *WebhookRepositoryMock) UpdateDelivery(delivery *model.WebhookDelivery) error {
+	args := repo.Called(delivery)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(error)
+}
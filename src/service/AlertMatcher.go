@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AlertMatcher periodically matches the offers queued by the JobAlertService
+// against the saved searches of all users and creates an alert for every
+// search an offer matches. An offer that could not be matched is tried again
+// after a delay that doubles with every failed attempt, up to MaxRetryDelay.
+type AlertMatcher struct {
+	JobAlertRepo    repository.IJobAlertRepository
+	SavedSearchRepo repository.ISavedSearchRepository
+	JobOfferRepo    repository.IJobOfferRepository
+	Interval        time.Duration
+	RetryDelay      time.Duration
+	MaxRetryDelay   time.Duration
+	BatchSize       int
+	Logger          *logrus.Entry
+}
+
+func (matcher *AlertMatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(matcher.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				matcher.Match(time.Now())
+			}
+		}
+	}()
+}
+
+// Match matches the queued offers and returns how many alerts were created.
+// An offer that could not be matched stays queued and does not hold back the
+// offers queued after it.
+func (matcher *AlertMatcher) Match(now time.Time) int {
+	checks, err := matcher.JobAlertRepo.GetPendingChecks(now, matcher.BatchSize)
+	if err != nil {
+		matcher.Logger.Debug(err.Error())
+		return 0
+	}
+
+	created := 0
+	for _, check := range checks {
+		saved, err := matcher.check(check, now)
+		if err != nil {
+			matcher.Logger.Debug(fmt.Sprintf("Error happened during matching job offer with id %d: %s", check.JobOfferID, err.Error()))
+			matcher.fail(check, err, now)
+			continue
+		}
+		created += saved
+	}
+
+	if created > 0 {
+		matcher.Logger.Info(fmt.Sprintf("Successfully created %d job alerts", created))
+	}
+	return created
+}
+
+func (matcher *AlertMatcher) check(check *model.AlertCheck, now time.Time) (int, error) {
+	offer, err := matcher.JobOfferRepo.GetById(check.JobOfferID)
+	if errors.Is(err, repository.ErrJobOfferNotFound) {
+		return matcher.JobAlertRepo.CompleteCheck(check.ID, nil, 0, now)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	alerts := []*model.JobAlert{}
+	for afterId := 0; ; {
+		searches, err := matcher.SavedSearchRepo.GetBatch(afterId, matcher.BatchSize)
+		if err != nil {
+			return 0, err
+		}
+		if len(searches) == 0 {
+			break
+		}
+
+		filters := map[int]repository.SearchFilter{}
+		users := map[int]int{}
+		for _, search := range searches {
+			filter, err := savedSearchFilter(search)
+			if err != nil {
+				matcher.Logger.Debug(fmt.Sprintf("Skipping saved search with id %d: %s", search.ID, err.Error()))
+				continue
+			}
+			filters[search.ID] = filter
+			users[search.ID] = search.UserID
+		}
+
+		matching, err := matcher.JobOfferRepo.MatchingSearches(offer.ID, filters)
+		if err != nil {
+			return 0, err
+		}
+		for _, searchId := range matching {
+			alerts = append(alerts, &model.JobAlert{UserID: users[searchId], SavedSearchID: searchId, JobOfferID: offer.ID})
+		}
+		afterId = searches[len(searches)-1].ID
+	}
+
+	return matcher.JobAlertRepo.CompleteCheck(check.ID, alerts, offer.CompanyID, now)
+}
+
+// fail records the failed attempt at the check and puts off the next one.
+func (matcher *AlertMatcher) fail(check *model.AlertCheck, cause error, now time.Time) {
+	attempts := check.Attempts + 1
+	nextAttemptAt := now.Add(backoff(matcher.RetryDelay, matcher.MaxRetryDelay, attempts-1))
+	if err := matcher.JobAlertRepo.FailCheck(check.ID, attempts, cause.Error(), nextAttemptAt); err != nil {
+		matcher.Logger.Debug(err.Error())
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AlertMatcherUnitTestsSuite struct {
+	suite.Suite
+	jobAlertRepositoryMock    *repository.JobAlertRepositoryMock
+	savedSearchRepositoryMock *repository.SavedSearchRepositoryMock
+	offerRepositoryMock       *repository.JobOfferRepositoryMock
+	matcher                   *AlertMatcher
+}
+
+func TestAlertMatcherUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(AlertMatcherUnitTestsSuite))
+}
+
+func (suite *AlertMatcherUnitTestsSuite) SetupTest() {
+	suite.jobAlertRepositoryMock = new(repository.JobAlertRepositoryMock)
+	suite.savedSearchRepositoryMock = new(repository.SavedSearchRepositoryMock)
+	suite.offerRepositoryMock = new(repository.JobOfferRepositoryMock)
+	suite.matcher = &AlertMatcher{
+		JobAlertRepo:    suite.jobAlertRepositoryMock,
+		SavedSearchRepo: suite.savedSearchRepositoryMock,
+		JobOfferRepo:    suite.offerRepositoryMock,
+		RetryDelay:      time.Minute,
+		MaxRetryDelay:   time.Hour,
+		BatchSize:       2,
+		Logger:          utils.Logger(),
+	}
+}
+
+func (suite *AlertMatcherUnitTestsSuite) TestAlertMatcher_Match_CreatesAlertsForMatchingSearches() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	offer := model.JobOffer{ID: 3, CompanyID: 7, Status: model.JobOfferStatusPublished}
+	goFilter := repository.SearchFilter{Statuses: []string{model.JobOfferStatusPublished}, Skills: []string{"go"}}
+	javaFilter := repository.SearchFilter{Statuses: []string{model.JobOfferStatusPublished}, Skills: []string{"java"}}
+	textFilter := repository.SearchFilter{Statuses: []string{model.JobOfferStatusPublished}, Text: "backend"}
+	suite.jobAlertRepositoryMock.On("GetPendingChecks", now, 2).Return([]*model.AlertCheck{{ID: 1, JobOfferID: 3}}, nil).Once()
+	suite.offerRepositoryMock.On("GetById", 3).Return(&offer, nil).Once()
+	suite.savedSearchRepositoryMock.On("GetBatch", 0, 2).Return([]*model.SavedSearch{
+		{ID: 1, UserID: 5, Filters: `{"skills":["go"]}`},
+		{ID: 2, UserID: 6, Filters: `{"skills":["java"]}`},
+	}, nil).Once()
+	suite.savedSearchRepositoryMock.On("GetBatch", 2, 2).Return([]*model.SavedSearch{
+		{ID: 4, UserID: 6, Query: "backend"},
+	}, nil).Once()
+	suite.savedSearchRepositoryMock.On("GetBatch", 4, 2).Return([]*model.SavedSearch{}, nil).Once()
+	suite.offerRepositoryMock.On("MatchingSearches", 3, map[int]repository.SearchFilter{1: goFilter, 2: javaFilter}).Return([]int{1}, nil).Once()
+	suite.offerRepositoryMock.On("MatchingSearches", 3, map[int]repository.SearchFilter{4: textFilter}).Return([]int{4}, nil).Once()
+	suite.jobAlertRepositoryMock.On("CompleteCheck", 1, []*model.JobAlert{
+		{UserID: 5, SavedSearchID: 1, JobOfferID: 3},
+		{UserID: 6, SavedSearchID: 4, JobOfferID: 3},
+	}, 7, now).Return(2, nil).Once()
+
+	created := suite.matcher.Match(now)
+
+	assert.Equal(suite.T(), 2, created)
+	suite.jobAlertRepositoryMock.AssertExpectations(suite.T())
+	suite.offerRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *AlertMatcherUnitTestsSuite) TestAlertMatcher_Match_CompletesChecksOfRemovedOffers() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	suite.jobAlertRepositoryMock.On("GetPendingChecks", now, 2).Return([]*model.AlertCheck{{ID: 1, JobOfferID: 3}}, nil).Once()
+	suite.offerRepositoryMock.On("GetById", 3).Return(nil, repository.ErrJobOfferNotFound).Once()
+	suite.jobAlertRepositoryMock.On("CompleteCheck", 1, []*model.JobAlert(nil), 0, now).Return(0, nil).Once()
+
+	created := suite.matcher.Match(now)
+
+	assert.Equal(suite.T(), 0, created)
+	suite.jobAlertRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *AlertMatcherUnitTestsSuite) TestAlertMatcher_Match_PutsOffFailedChecks() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	offer := model.JobOffer{ID: 4, CompanyID: 7}
+	suite.jobAlertRepositoryMock.On("GetPendingChecks", now, 2).Return([]*model.AlertCheck{{ID: 1, JobOfferID: 3, Attempts: 2}, {ID: 2, JobOfferID: 4}}, nil).Once()
+	suite.offerRepositoryMock.On("GetById", 3).Return(nil, errors.New("database is down")).Once()
+	suite.jobAlertRepositoryMock.On("FailCheck", 1, 3, "database is down", now.Add(4*time.Minute)).Return(nil).Once()
+	suite.offerRepositoryMock.On("GetById", 4).Return(&offer, nil).Once()
+	suite.savedSearchRepositoryMock.On("GetBatch", 0, 2).Return([]*model.SavedSearch{}, nil).Once()
+	suite.jobAlertRepositoryMock.On("CompleteCheck", 2, []*model.JobAlert{}, 7, now).Return(0, nil).Once()
+
+	created := suite.matcher.Match(now)
+
+	assert.Equal(suite.T(), 0, created)
+	suite.jobAlertRepositoryMock.AssertExpectations(suite.T())
+	suite.offerRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *AlertMatcherUnitTestsSuite) TestAlertMatcher_Match_CapsRetryDelay() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	suite.jobAlertRepositoryMock.On("GetPendingChecks", now, 2).Return([]*model.AlertCheck{{ID: 1, JobOfferID: 3, Attempts: 10}}, nil).Once()
+	suite.offerRepositoryMock.On("GetById", 3).Return(&model.JobOffer{ID: 3}, nil).Once()
+	suite.savedSearchRepositoryMock.On("GetBatch", 0, 2).Return(nil, errors.New("database is down")).Once()
+	suite.jobAlertRepositoryMock.On("FailCheck", 1, 11, "database is down", now.Add(time.Hour)).Return(nil).Once()
+
+	created := suite.matcher.Match(now)
+
+	assert.Equal(suite.T(), 0, created)
+	suite.jobAlertRepositoryMock.AssertExpectations(suite.T())
+	suite.jobAlertRepositoryMock.AssertNotCalled(suite.T(), "CompleteCheck", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
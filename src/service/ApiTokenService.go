@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// apiTokenPrefixLength is the length of the start of a token kept to tell
+// tokens apart.
+const apiTokenPrefixLength = len(auth.ApiTokenPrefix) + 8
+
+// ApiTokenService manages the API tokens companies push job offers with.
+// Tokens are managed by members of their company, like its offers.
+type ApiTokenService struct {
+	ApiTokenRepo repository.IApiTokenRepository
+	MemberRepo   repository.ICompanyMemberRepository
+	AuditRepo    repository.IAuditRepository
+	Logger       *logrus.Entry
+}
+
+type IApiTokenService interface {
+	Add(context.Context, int, *dto.ApiTokenRequestDTO) (*dto.ApiTokenResponseDTO, error)
+	GetCompanysApiTokens(context.Context, int) ([]*dto.ApiTokenResponseDTO, error)
+	Revoke(context.Context, int, int) error
+	Verify(string) (*auth.Identity, error)
+}
+
+func NewApiTokenService(apiTokenRepository repository.IApiTokenRepository, memberRepository repository.ICompanyMemberRepository, auditRepository repository.IAuditRepository, logger *logrus.Entry) IApiTokenService {
+	return &ApiTokenService{
+		apiTokenRepository,
+		memberRepository,
+		auditRepository,
+		logger,
+	}
+}
+
+// Add creates a token for the company. Tokens created without scopes get every
+// scope. The token is returned only in this response, only its hash is kept.
+func (service *ApiTokenService) Add(ctx context.Context, companyId int, tokenDTO *dto.ApiTokenRequestDTO) (*dto.ApiTokenResponseDTO, error) {
+	if err := tokenDTO.Validate(); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+	if tokenDTO.ExpiresAt != nil && !tokenDTO.ExpiresAt.After(time.Now()) {
+		err := errors.New("API token should expire in the future")
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if err := service.authorize(ctx, model.AuditActionCreateApiToken, companyId); err != nil {
+		return nil, err
+	}
+
+	token, err := auth.NewApiToken()
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	entity := mapper.ApiTokenRequestDTOToApiToken(companyId, tokenDTO)
+	if len(entity.Scopes) == 0 {
+		entity.Scopes = append([]string{}, auth.Scopes...)
+	}
+	entity.Prefix = token[:apiTokenPrefixLength]
+	entity.Hash = auth.HashApiToken(token)
+
+	service.Logger.Info(fmt.Sprintf("Adding new API token in database for company %d", companyId))
+
+	addedEntity, err := service.ApiTokenRepo.Add(*entity)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully added new API token in database with id %d", addedEntity.ID))
+	res := mapper.ApiTokenToApiTokenResponseDTO(&addedEntity)
+	res.Token = token
+	return res, nil
+}
+
+// GetCompanysApiTokens returns every token of the company, including the
+// revoked and expired ones, with when they were last used.
+func (service *ApiTokenService) GetCompanysApiTokens(ctx context.Context, companyId int) ([]*dto.ApiTokenResponseDTO, error) {
+	if err := service.authorize(ctx, model.AuditActionListApiTokens, companyId); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting API tokens from database for company %d", companyId))
+	tokens, err := service.ApiTokenRepo.GetByCompany(companyId)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	res := make([]*dto.ApiTokenResponseDTO, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		res[i] = mapper.ApiTokenToApiTokenResponseDTO(tokens[i])
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got API tokens from database for company %d", companyId))
+	return res, nil
+}
+
+// Revoke revokes the token of the company. Revoking a revoked token does
+// nothing.
+func (service *ApiTokenService) Revoke(ctx context.Context, companyId int, id int) error {
+	if err := service.authorize(ctx, model.AuditActionRevokeApiToken, companyId); err != nil {
+		return err
+	}
+
+	token, err := service.ApiTokenRepo.GetById(id)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+	if token.CompanyID != companyId {
+		err := fmt.Errorf("%w: API token with id %d belongs to another company", repository.ErrApiTokenNotFound, id)
+		service.Logger.Debug(err.Error())
+		return err
+	}
+	if token.RevokedAt != nil {
+		return nil
+	}
+
+	service.Logger.Info(fmt.Sprintf("Revoking API token in database with id %d", id))
+	if err := service.ApiTokenRepo.Revoke(id, time.Now()); err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully revoked API token in database with id %d", id))
+	return nil
+}
+
+// Verify returns the identity of the token, scoped to its company, and
+// records when the token was used. Revoked and expired tokens are rejected.
+func (service *ApiTokenService) Verify(token string) (*auth.Identity, error) {
+	entity, err := service.ApiTokenRepo.GetByHash(auth.HashApiToken(token))
+	if errors.Is(err, repository.ErrApiTokenNotFound) {
+		return nil, fmt.Errorf("%w: unknown API token", auth.ErrInvalidToken)
+	}
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+	if !entity.IsActive(now) {
+		return nil, fmt.Errorf("%w: API token %d is revoked or expired", auth.ErrInvalidToken, entity.ID)
+	}
+
+	if err := service.ApiTokenRepo.Touch(entity.ID, now); err != nil {
+		service.Logger.Debug(err.Error())
+	}
+
+	return &auth.Identity{
+		Companies: []int{entity.CompanyID},
+		TokenID:   entity.ID,
+		Scopes:    entity.Scopes,
+	}, nil
+}
+
+func (service *ApiTokenService) authorize(ctx context.Context, action string, companyId int) error {
+	authorizer := companyAuthorizer{MemberRepo: service.MemberRepo, AuditRepo: service.AuditRepo, Logger: service.Logger}
+	return authorizer.authorize(ctx, action, companyId, nil)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ApiTokenServiceUnitTestsSuite struct {
+	suite.Suite
+	apiTokenRepositoryMock *repository.ApiTokenRepositoryMock
+	memberRepositoryMock   *repository.CompanyMemberRepositoryMock
+	auditRepositoryMock    *repository.AuditRepositoryMock
+	service                IApiTokenService
+	ctx                    context.Context
+}
+
+func TestApiTokenServiceUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(ApiTokenServiceUnitTestsSuite))
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) SetupTest() {
+	suite.apiTokenRepositoryMock = new(repository.ApiTokenRepositoryMock)
+	suite.memberRepositoryMock = new(repository.CompanyMemberRepositoryMock)
+	suite.auditRepositoryMock = new(repository.AuditRepositoryMock)
+	suite.service = NewApiTokenService(suite.apiTokenRepositoryMock, suite.memberRepositoryMock, suite.auditRepositoryMock, utils.Logger())
+	suite.ctx = auth.NewContext(context.Background(), &auth.Identity{UserID: 4, Roles: []string{auth.RoleCompanyAdmin}})
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Add_StoresOnlyHash() {
+	var stored model.ApiToken
+	suite.memberRepositoryMock.On("IsMember", 4, 1).Return(true, nil).Once()
+	suite.apiTokenRepositoryMock.On("Add", mock.MatchedBy(func(token model.ApiToken) bool {
+		stored = token
+		return token.CompanyID == 1 && token.Name == "ATS"
+	})).Return(model.ApiToken{ID: 3, CompanyID: 1, Name: "ATS", Prefix: "jobs_12345678", Scopes: []string{auth.ScopeCreateJobOffers}}, nil).Once()
+
+	token, err := suite.service.Add(suite.ctx, 1, &dto.ApiTokenRequestDTO{Name: "ATS"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 3, token.ID)
+	assert.True(suite.T(), strings.HasPrefix(token.Token, auth.ApiTokenPrefix))
+	assert.Equal(suite.T(), auth.HashApiToken(token.Token), stored.Hash)
+	assert.Equal(suite.T(), token.Token[:apiTokenPrefixLength], stored.Prefix)
+	assert.Equal(suite.T(), auth.Scopes, []string(stored.Scopes))
+	assert.NotContains(suite.T(), stored.Hash, token.Token)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Add_ExpiredFails() {
+	expiresAt := time.Now().Add(-time.Hour)
+
+	token, err := suite.service.Add(suite.ctx, 1, &dto.ApiTokenRequestDTO{Name: "ATS", ExpiresAt: &expiresAt})
+
+	assert.Nil(suite.T(), token)
+	assert.NotNil(suite.T(), err)
+	suite.apiTokenRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Add_UnknownScopeFails() {
+	token, err := suite.service.Add(suite.ctx, 1, &dto.ApiTokenRequestDTO{Name: "ATS", Scopes: []string{"job-offers:delete"}})
+
+	assert.Nil(suite.T(), token)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Add_NonMemberForbidden() {
+	userId := 4
+	entry := model.AuditEntry{UserID: &userId, CompanyID: 2, Action: model.AuditActionCreateApiToken, Reason: "user 4 is not a member of company 2"}
+	suite.memberRepositoryMock.On("IsMember", 4, 2).Return(false, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	token, err := suite.service.Add(suite.ctx, 2, &dto.ApiTokenRequestDTO{Name: "ATS"})
+
+	assert.Nil(suite.T(), token)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.auditRepositoryMock.AssertCalled(suite.T(), "Add", entry)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Add_ApiTokenForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{TokenID: 9, Companies: []int{1}, Scopes: auth.Scopes})
+	tokenId := 9
+	entry := model.AuditEntry{ApiTokenID: &tokenId, CompanyID: 1, Action: model.AuditActionCreateApiToken, Reason: "API token 9 may only create job offers"}
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	token, err := suite.service.Add(ctx, 1, &dto.ApiTokenRequestDTO{Name: "ATS"})
+
+	assert.Nil(suite.T(), token)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_GetCompanysApiTokens() {
+	lastUsedAt := time.Now()
+	suite.memberRepositoryMock.On("IsMember", 4, 1).Return(true, nil).Once()
+	suite.apiTokenRepositoryMock.On("GetByCompany", 1).Return([]*model.ApiToken{{ID: 3, CompanyID: 1, Hash: "hash", LastUsedAt: &lastUsedAt}}, nil).Once()
+
+	tokens, err := suite.service.GetCompanysApiTokens(suite.ctx, 1)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(tokens))
+	assert.Equal(suite.T(), &lastUsedAt, tokens[0].LastUsedAt)
+	assert.Empty(suite.T(), tokens[0].Token)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Revoke_Pass() {
+	suite.memberRepositoryMock.On("IsMember", 4, 1).Return(true, nil).Once()
+	suite.apiTokenRepositoryMock.On("GetById", 3).Return(&model.ApiToken{ID: 3, CompanyID: 1}, nil).Once()
+	suite.apiTokenRepositoryMock.On("Revoke", 3, mock.Anything).Return(nil).Once()
+
+	err := suite.service.Revoke(suite.ctx, 1, 3)
+
+	assert.Nil(suite.T(), err)
+	suite.apiTokenRepositoryMock.AssertCalled(suite.T(), "Revoke", 3, mock.Anything)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Revoke_OtherCompanysToken() {
+	suite.memberRepositoryMock.On("IsMember", 4, 1).Return(true, nil).Once()
+	suite.apiTokenRepositoryMock.On("GetById", 3).Return(&model.ApiToken{ID: 3, CompanyID: 2}, nil).Once()
+
+	err := suite.service.Revoke(suite.ctx, 1, 3)
+
+	assert.ErrorIs(suite.T(), err, repository.ErrApiTokenNotFound)
+	suite.apiTokenRepositoryMock.AssertNotCalled(suite.T(), "Revoke", mock.Anything, mock.Anything)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Verify_RecordsUse() {
+	suite.apiTokenRepositoryMock.On("GetByHash", auth.HashApiToken("jobs_token")).Return(&model.ApiToken{ID: 3, CompanyID: 1, Scopes: auth.Scopes}, nil).Once()
+	suite.apiTokenRepositoryMock.On("Touch", 3, mock.Anything).Return(nil).Once()
+
+	identity, err := suite.service.Verify("jobs_token")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 3, identity.TokenID)
+	assert.True(suite.T(), identity.IsMemberOf(1))
+	assert.True(suite.T(), identity.HasScope(auth.ScopeCreateJobOffers))
+	suite.apiTokenRepositoryMock.AssertCalled(suite.T(), "Touch", 3, mock.Anything)
+}
+
+func (suite *ApiTokenServiceUnitTestsSuite) TestApiTokenService_Verify_InactiveTokensRejected() {
+	past := time.Now().Add(-time.Minute)
+	suite.apiTokenRepositoryMock.On("GetByHash", auth.HashApiToken("jobs_unknown")).Return(nil, repository.ErrApiTokenNotFound).Once()
+	suite.apiTokenRepositoryMock.On("GetByHash", auth.HashApiToken("jobs_revoked")).Return(&model.ApiToken{ID: 4, CompanyID: 1, RevokedAt: &past}, nil).Once()
+	suite.apiTokenRepositoryMock.On("GetByHash", auth.HashApiToken("jobs_expired")).Return(&model.ApiToken{ID: 5, CompanyID: 1, ExpiresAt: &past}, nil).Once()
+
+	for _, token := range []string{"jobs_unknown", "jobs_revoked", "jobs_expired"} {
+		identity, err := suite.service.Verify(token)
+
+		assert.Nil(suite.T(), identity)
+		assert.ErrorIs(suite.T(), err, auth.ErrInvalidToken)
+	}
+	suite.apiTokenRepositoryMock.AssertNotCalled(suite.T(), "Touch", mock.Anything, mock.Anything)
+}
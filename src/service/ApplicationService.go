@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrOfferNotAcceptingApplications = errors.New("Job offer is not accepting applications")
+var ErrUnknownApplicationStage = errors.New("Unknown application stage")
+var ErrInvalidStageTransition = errors.New("Invalid application stage transition")
+
+type ApplicationService struct {
+	ApplicationRepo repository.IApplicationRepository
+	JobOfferRepo    repository.IJobOfferRepository
+	MemberRepo      repository.ICompanyMemberRepository
+	AuditRepo       repository.IAuditRepository
+	Logger          *logrus.Entry
+}
+
+type IApplicationService interface {
+	Apply(context.Context, int, *dto.ApplicationRequestDTO) (*dto.ApplicationResponseDTO, error)
+	GetJobOffersApplications(context.Context, int) ([]*dto.ApplicationResponseDTO, error)
+	GetCandidatesApplications(int) ([]*dto.ApplicationResponseDTO, error)
+	ChangeStage(context.Context, int, *dto.ApplicationStageRequestDTO) (*dto.ApplicationResponseDTO, error)
+	GetHistory(context.Context, int) ([]*dto.ApplicationStageChangeResponseDTO, error)
+}
+
+func NewApplicationService(applicationRepository repository.IApplicationRepository, jobOfferRepository repository.IJobOfferRepository, memberRepository repository.ICompanyMemberRepository, auditRepository repository.IAuditRepository, logger *logrus.Entry) IApplicationService {
+	return &ApplicationService{
+		applicationRepository,
+		jobOfferRepository,
+		memberRepository,
+		auditRepository,
+		logger,
+	}
+}
+
+// Apply saves the application of the user in ctx to a published offer that is
+// currently valid, placing it in the first stage of the offer. A candidate can
+// apply to an offer only once.
+func (service *ApplicationService) Apply(ctx context.Context, jobOfferId int, applicationDTO *dto.ApplicationRequestDTO) (*dto.ApplicationResponseDTO, error) {
+	if err := applicationDTO.Validate(); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.IsApiToken() {
+		err := fmt.Errorf("%w: only users can apply to job offer with id %d", ErrForbidden, jobOfferId)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	offer, err := service.JobOfferRepo.GetById(jobOfferId)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if err := acceptsApplications(offer, time.Now()); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	entity := mapper.ApplicationRequestDTOToApplication(jobOfferId, identity.UserID, applicationDTO)
+	entity.Stage = offer.ApplicationStages()[0]
+
+	service.Logger.Info(fmt.Sprintf("Adding application of candidate %d to job offer with id %d", entity.CandidateID, jobOfferId))
+
+	addedEntity, err := service.ApplicationRepo.Add(*entity)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully added application in database with id %d", addedEntity.ID))
+	return mapper.ApplicationToApplicationResponseDTO(&addedEntity), nil
+}
+
+// GetJobOffersApplications returns the applications to the offer when the
+// caller in ctx may manage the offers of its company.
+func (service *ApplicationService) GetJobOffersApplications(ctx context.Context, jobOfferId int) ([]*dto.ApplicationResponseDTO, error) {
+	offer, err := service.JobOfferRepo.GetById(jobOfferId)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if err := service.authorize(ctx, model.AuditActionListApplications, offer.CompanyID, &jobOfferId); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting applications from database for job offer with id %d", jobOfferId))
+	applications, err := service.ApplicationRepo.GetByJobOffer(jobOfferId)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got applications from database for job offer with id %d", jobOfferId))
+	return toApplicationResponseDTOs(applications), nil
+}
+
+func (service *ApplicationService) GetCandidatesApplications(candidateId int) ([]*dto.ApplicationResponseDTO, error) {
+	service.Logger.Info(fmt.Sprintf("Getting applications from database for candidate %d", candidateId))
+	applications, err := service.ApplicationRepo.GetByCandidate(candidateId)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	res := toApplicationResponseDTOs(applications)
+	for i, application := range applications {
+		res[i].JobOfferRemoved = application.JobOffer.ID == 0
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got applications from database for candidate %d", candidateId))
+	return res, nil
+}
+
+// ChangeStage moves the application to another of the stages of its offer
+// when the caller in ctx may manage the offers of its company. The event of
+// the change is published through the outbox.
+func (service *ApplicationService) ChangeStage(ctx context.Context, id int, stageDTO *dto.ApplicationStageRequestDTO) (*dto.ApplicationResponseDTO, error) {
+	if err := stageDTO.Validate(); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	application, err := service.getManagedApplication(ctx, model.AuditActionChangeStage, id)
+	if err != nil {
+		return nil, err
+	}
+	if !containsStage(application.JobOffer.ApplicationStages(), stageDTO.Stage) {
+		err := fmt.Errorf("%w: %s is not a stage of job offer with id %d", ErrUnknownApplicationStage, stageDTO.Stage, application.JobOfferID)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+	if application.Stage == stageDTO.Stage {
+		err := fmt.Errorf("%w: application with id %d is already in stage %s", ErrInvalidStageTransition, id, stageDTO.Stage)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	companyId := application.JobOffer.CompanyID
+	application.JobOffer = model.JobOffer{}
+	change := model.ApplicationStageChange{
+		ApplicationID: id,
+		Application:   *application,
+		FromStage:     application.Stage,
+		ToStage:       stageDTO.Stage,
+		Note:          stageDTO.Note,
+	}
+
+	service.Logger.Info(fmt.Sprintf("Moving application with id %d from stage %s to %s", id, change.FromStage, change.ToStage))
+
+	savedChange, err := service.ApplicationRepo.ChangeStage(change, companyId)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully moved application with id %d to stage %s", id, savedChange.ToStage))
+	return mapper.ApplicationToApplicationResponseDTO(&savedChange.Application), nil
+}
+
+// GetHistory returns the stage changes of the application when the caller in
+// ctx may manage the offers of its company.
+func (service *ApplicationService) GetHistory(ctx context.Context, id int) ([]*dto.ApplicationStageChangeResponseDTO, error) {
+	if _, err := service.getManagedApplication(ctx, model.AuditActionListStageChanges, id); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting history from database for application with id %d", id))
+	changes, err := service.ApplicationRepo.GetHistory(id)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got history from database for application with id %d", id))
+	res := make([]*dto.ApplicationStageChangeResponseDTO, len(changes))
+	for i := 0; i < len(changes); i++ {
+		res[i] = mapper.ApplicationStageChangeToApplicationStageChangeResponseDTO(changes[i])
+	}
+	return res, nil
+}
+
+// getManagedApplication returns the application, with its offer, when the
+// caller in ctx may manage the offers of the company of the offer.
+func (service *ApplicationService) getManagedApplication(ctx context.Context, action string, id int) (*model.Application, error) {
+	application, err := service.ApplicationRepo.GetById(id)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if application.JobOffer.ID == 0 {
+		err := fmt.Errorf("%w: job offer with id %d of application with id %d was removed", repository.ErrJobOfferNotFound, application.JobOfferID, id)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if err := service.authorize(ctx, action, application.JobOffer.CompanyID, &application.JobOfferID); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+func (service *ApplicationService) authorize(ctx context.Context, action string, companyId int, offerId *int) error {
+	authorizer := companyAuthorizer{MemberRepo: service.MemberRepo, AuditRepo: service.AuditRepo, Logger: service.Logger}
+	return authorizer.authorize(ctx, action, companyId, offerId)
+}
+
+func containsStage(stages []string, stage string) bool {
+	for _, s := range stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptsApplications(offer *model.JobOffer, now time.Time) error {
+	if offer.Status != model.JobOfferStatusPublished {
+		return fmt.Errorf("%w: job offer with id %d is %s", ErrOfferNotAcceptingApplications, offer.ID, offer.Status)
+	}
+	if offer.ValidFrom != nil && offer.ValidFrom.After(now) {
+		return fmt.Errorf("%w: job offer with id %d is valid from %s", ErrOfferNotAcceptingApplications, offer.ID, offer.ValidFrom.Format(time.RFC3339))
+	}
+	if offer.ValidUntil != nil && !offer.ValidUntil.After(now) {
+		return fmt.Errorf("%w: job offer with id %d was valid until %s", ErrOfferNotAcceptingApplications, offer.ID, offer.ValidUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func toApplicationResponseDTOs(applications []*model.Application) []*dto.ApplicationResponseDTO {
+	res := make([]*dto.ApplicationResponseDTO, len(applications))
+	for i := 0; i < len(applications); i++ {
+		res[i] = mapper.ApplicationToApplicationResponseDTO(applications[i])
+	}
+	return res
+}
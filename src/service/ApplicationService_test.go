@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ApplicationServiceUnitTestsSuite struct {
+	suite.Suite
+	applicationRepositoryMock *repository.ApplicationRepositoryMock
+	offerRepositoryMock       *repository.JobOfferRepositoryMock
+	memberRepositoryMock      *repository.CompanyMemberRepositoryMock
+	auditRepositoryMock       *repository.AuditRepositoryMock
+	service                   IApplicationService
+	ctx                       context.Context
+	candidate                 context.Context
+}
+
+func TestApplicationServiceUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(ApplicationServiceUnitTestsSuite))
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) SetupTest() {
+	suite.applicationRepositoryMock = new(repository.ApplicationRepositoryMock)
+	suite.offerRepositoryMock = new(repository.JobOfferRepositoryMock)
+	suite.memberRepositoryMock = new(repository.CompanyMemberRepositoryMock)
+	suite.auditRepositoryMock = new(repository.AuditRepositoryMock)
+	suite.service = NewApplicationService(suite.applicationRepositoryMock, suite.offerRepositoryMock, suite.memberRepositoryMock, suite.auditRepositoryMock, utils.Logger())
+	suite.ctx = auth.NewContext(context.Background(), &auth.Identity{UserID: 1, Roles: []string{auth.RolePlatformAdmin}})
+	suite.candidate = auth.NewContext(context.Background(), &auth.Identity{UserID: 7})
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_Apply_ReturnsApplication() {
+	applicationDTO := dto.ApplicationRequestDTO{CoverLetter: "Hello"}
+	offer := model.JobOffer{ID: 1, Status: model.JobOfferStatusPublished}
+	suite.offerRepositoryMock.On("GetById", 1).Return(&offer, nil).Once()
+	suite.applicationRepositoryMock.On("Add", model.Application{JobOfferID: 1, CandidateID: 7, CoverLetter: "Hello", Stage: model.ApplicationStageApplied}).
+		Return(model.Application{ID: 2, JobOfferID: 1, CandidateID: 7, CoverLetter: "Hello"}, nil).Once()
+
+	application, err := suite.service.Apply(suite.candidate, 1, &applicationDTO)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, application.ID)
+	assert.Equal(suite.T(), 7, application.CandidateID)
+	suite.applicationRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_Apply_AnonymousForbidden() {
+	applicationDTO := dto.ApplicationRequestDTO{CoverLetter: "Hello"}
+	apiToken := auth.NewContext(context.Background(), &auth.Identity{TokenID: 6, Companies: []int{4}})
+
+	for _, ctx := range []context.Context{context.Background(), apiToken} {
+		application, err := suite.service.Apply(ctx, 1, &applicationDTO)
+
+		assert.Nil(suite.T(), application)
+		assert.ErrorIs(suite.T(), err, ErrForbidden)
+	}
+	suite.applicationRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything)
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_Apply_OfferNotFound() {
+	applicationDTO := dto.ApplicationRequestDTO{}
+	suite.offerRepositoryMock.On("GetById", 1).Return(nil, repository.ErrJobOfferNotFound).Once()
+
+	application, err := suite.service.Apply(suite.candidate, 1, &applicationDTO)
+
+	assert.Nil(suite.T(), application)
+	assert.True(suite.T(), errors.Is(err, repository.ErrJobOfferNotFound))
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_Apply_ClosedOfferFails() {
+	applicationDTO := dto.ApplicationRequestDTO{}
+	offer := model.JobOffer{ID: 1, Status: model.JobOfferStatusClosed}
+	suite.offerRepositoryMock.On("GetById", 1).Return(&offer, nil).Once()
+
+	application, err := suite.service.Apply(suite.candidate, 1, &applicationDTO)
+
+	assert.Nil(suite.T(), application)
+	assert.True(suite.T(), errors.Is(err, ErrOfferNotAcceptingApplications))
+	suite.applicationRepositoryMock.AssertNotCalled(suite.T(), "Add")
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_Apply_ExpiredOfferFails() {
+	applicationDTO := dto.ApplicationRequestDTO{}
+	validUntil := time.Now().Add(-time.Hour)
+	offer := model.JobOffer{ID: 1, Status: model.JobOfferStatusPublished, ValidUntil: &validUntil}
+	suite.offerRepositoryMock.On("GetById", 1).Return(&offer, nil).Once()
+
+	application, err := suite.service.Apply(suite.candidate, 1, &applicationDTO)
+
+	assert.Nil(suite.T(), application)
+	assert.True(suite.T(), errors.Is(err, ErrOfferNotAcceptingApplications))
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_Apply_DuplicateFails() {
+	applicationDTO := dto.ApplicationRequestDTO{}
+	offer := model.JobOffer{ID: 1, Status: model.JobOfferStatusPublished}
+	suite.offerRepositoryMock.On("GetById", 1).Return(&offer, nil).Once()
+	suite.applicationRepositoryMock.On("Add", model.Application{JobOfferID: 1, CandidateID: 7, Stage: model.ApplicationStageApplied}).
+		Return(model.Application{}, repository.ErrDuplicateApplication).Once()
+
+	application, err := suite.service.Apply(suite.candidate, 1, &applicationDTO)
+
+	assert.Nil(suite.T(), application)
+	assert.True(suite.T(), errors.Is(err, repository.ErrDuplicateApplication))
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_GetJobOffersApplications_OfferNotFound() {
+	suite.offerRepositoryMock.On("GetById", 1).Return(nil, repository.ErrJobOfferNotFound).Once()
+
+	applications, err := suite.service.GetJobOffersApplications(suite.ctx, 1)
+
+	assert.Nil(suite.T(), applications)
+	assert.True(suite.T(), errors.Is(err, repository.ErrJobOfferNotFound))
+	suite.applicationRepositoryMock.AssertNotCalled(suite.T(), "GetByJobOffer", 1)
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_GetCandidatesApplications_IncludesOffer() {
+	applications := []*model.Application{{ID: 2, JobOfferID: 1, CandidateID: 7, JobOffer: model.JobOffer{ID: 1, Position: "Developer"}}}
+	suite.applicationRepositoryMock.On("GetByCandidate", 7).Return(applications, nil).Once()
+
+	res, err := suite.service.GetCandidatesApplications(7)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(res))
+	assert.Equal(suite.T(), "Developer", res[0].JobOffer.Position)
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_GetCandidatesApplications_FlagsRemovedOffers() {
+	applications := []*model.Application{{ID: 3, JobOfferID: 9, CandidateID: 8, Stage: model.ApplicationStageInterview}}
+	suite.applicationRepositoryMock.On("GetByCandidate", 8).Return(applications, nil).Once()
+
+	res, err := suite.service.GetCandidatesApplications(8)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(res))
+	assert.Equal(suite.T(), 9, res[0].JobOfferID)
+	assert.Nil(suite.T(), res[0].JobOffer)
+	assert.True(suite.T(), res[0].JobOfferRemoved)
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_ChangeStage_RemovedOfferFails() {
+	application := model.Application{ID: 4, JobOfferID: 9, Stage: model.ApplicationStageApplied}
+	suite.applicationRepositoryMock.On("GetById", 4).Return(&application, nil).Once()
+
+	res, err := suite.service.ChangeStage(suite.ctx, 4, &dto.ApplicationStageRequestDTO{Stage: model.ApplicationStageScreening})
+
+	assert.Nil(suite.T(), res)
+	assert.True(suite.T(), errors.Is(err, repository.ErrJobOfferNotFound))
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_Apply_StartsInFirstStageOfOffer() {
+	applicationDTO := dto.ApplicationRequestDTO{}
+	offer := model.JobOffer{ID: 1, Status: model.JobOfferStatusPublished, Stages: []string{"new", "call", "done"}}
+	suite.offerRepositoryMock.On("GetById", 1).Return(&offer, nil).Once()
+	suite.applicationRepositoryMock.On("Add", model.Application{JobOfferID: 1, CandidateID: 7, Stage: "new"}).
+		Return(model.Application{ID: 2, JobOfferID: 1, CandidateID: 7, Stage: "new"}, nil).Once()
+
+	application, err := suite.service.Apply(suite.candidate, 1, &applicationDTO)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "new", application.Stage)
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_ChangeStage_SavesChange() {
+	application := model.Application{ID: 2, JobOfferID: 1, CandidateID: 7, Stage: model.ApplicationStageApplied, JobOffer: model.JobOffer{ID: 1, CompanyID: 4}}
+	change := model.ApplicationStageChange{
+		ApplicationID: 2,
+		Application:   model.Application{ID: 2, JobOfferID: 1, CandidateID: 7, Stage: model.ApplicationStageApplied},
+		FromStage:     model.ApplicationStageApplied,
+		ToStage:       model.ApplicationStageInterview,
+		Note:          "Skipping screening",
+	}
+	saved := change
+	saved.Application.Stage = model.ApplicationStageInterview
+	suite.applicationRepositoryMock.On("GetById", 2).Return(&application, nil).Once()
+	suite.applicationRepositoryMock.On("ChangeStage", change, 4).Return(saved, nil).Once()
+
+	res, err := suite.service.ChangeStage(suite.ctx, 2, &dto.ApplicationStageRequestDTO{Stage: model.ApplicationStageInterview, Note: "Skipping screening"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), model.ApplicationStageInterview, res.Stage)
+	suite.applicationRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_ChangeStage_UnknownStageFails() {
+	application := model.Application{ID: 2, Stage: "new", JobOffer: model.JobOffer{ID: 1, Stages: []string{"new", "call", "done"}}}
+	suite.applicationRepositoryMock.On("GetById", 2).Return(&application, nil).Once()
+
+	res, err := suite.service.ChangeStage(suite.ctx, 2, &dto.ApplicationStageRequestDTO{Stage: model.ApplicationStageInterview})
+
+	assert.Nil(suite.T(), res)
+	assert.True(suite.T(), errors.Is(err, ErrUnknownApplicationStage))
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_ChangeStage_SameStageFails() {
+	application := model.Application{ID: 2, Stage: model.ApplicationStageScreening, JobOffer: model.JobOffer{ID: 1}}
+	suite.applicationRepositoryMock.On("GetById", 2).Return(&application, nil).Once()
+
+	res, err := suite.service.ChangeStage(suite.ctx, 2, &dto.ApplicationStageRequestDTO{Stage: model.ApplicationStageScreening})
+
+	assert.Nil(suite.T(), res)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidStageTransition))
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_GetHistory_ApplicationNotFound() {
+	suite.applicationRepositoryMock.On("GetById", 2).Return(nil, repository.ErrApplicationNotFound).Once()
+
+	history, err := suite.service.GetHistory(suite.ctx, 2)
+
+	assert.Nil(suite.T(), history)
+	assert.True(suite.T(), errors.Is(err, repository.ErrApplicationNotFound))
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_GetJobOffersApplications_NonMemberForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 30, Roles: []string{auth.RoleCompanyAdmin}})
+	userId, offerId := 30, 1
+	entry := model.AuditEntry{UserID: &userId, CompanyID: 8, JobOfferID: &offerId, Action: model.AuditActionListApplications, Reason: "user 30 is not a member of company 8"}
+	suite.offerRepositoryMock.On("GetById", 1).Return(&model.JobOffer{ID: 1, CompanyID: 8}, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 30, 8).Return(false, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	applications, err := suite.service.GetJobOffersApplications(ctx, 1)
+
+	assert.Nil(suite.T(), applications)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.auditRepositoryMock.AssertExpectations(suite.T())
+	suite.applicationRepositoryMock.AssertNotCalled(suite.T(), "GetByJobOffer", 1)
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_ChangeStage_MemberAllowed() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 31, Roles: []string{auth.RoleCompanyAdmin}})
+	application := model.Application{ID: 2, JobOfferID: 1, Stage: model.ApplicationStageApplied, JobOffer: model.JobOffer{ID: 1, CompanyID: 4}}
+	change := model.ApplicationStageChange{
+		ApplicationID: 2,
+		Application:   model.Application{ID: 2, JobOfferID: 1, Stage: model.ApplicationStageApplied},
+		FromStage:     model.ApplicationStageApplied,
+		ToStage:       model.ApplicationStageScreening,
+	}
+	saved := change
+	saved.Application.Stage = model.ApplicationStageScreening
+	suite.applicationRepositoryMock.On("GetById", 2).Return(&application, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 31, 4).Return(true, nil).Once()
+	suite.applicationRepositoryMock.On("ChangeStage", change, 4).Return(saved, nil).Once()
+
+	res, err := suite.service.ChangeStage(ctx, 2, &dto.ApplicationStageRequestDTO{Stage: model.ApplicationStageScreening})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), model.ApplicationStageScreening, res.Stage)
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_ChangeStage_AnonymousForbidden() {
+	application := model.Application{ID: 2, JobOfferID: 1, Stage: model.ApplicationStageApplied, JobOffer: model.JobOffer{ID: 1, CompanyID: 4}}
+	offerId := 1
+	entry := model.AuditEntry{CompanyID: 4, JobOfferID: &offerId, Action: model.AuditActionChangeStage, Reason: "caller is not authenticated"}
+	suite.applicationRepositoryMock.On("GetById", 2).Return(&application, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	res, err := suite.service.ChangeStage(context.Background(), 2, &dto.ApplicationStageRequestDTO{Stage: model.ApplicationStageScreening})
+
+	assert.Nil(suite.T(), res)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.applicationRepositoryMock.AssertNotCalled(suite.T(), "ChangeStage", mock.Anything, mock.Anything)
+}
+
+func (suite *ApplicationServiceUnitTestsSuite) TestApplicationService_GetHistory_ApiTokenForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{TokenID: 6, Companies: []int{4}, Scopes: []string{auth.ScopeCreateJobOffers}})
+	application := model.Application{ID: 2, JobOfferID: 1, JobOffer: model.JobOffer{ID: 1, CompanyID: 4}}
+	tokenId, offerId := 6, 1
+	entry := model.AuditEntry{ApiTokenID: &tokenId, CompanyID: 4, JobOfferID: &offerId, Action: model.AuditActionListStageChanges, Reason: "API token 6 may only create job offers"}
+	suite.applicationRepositoryMock.On("GetById", 2).Return(&application, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	history, err := suite.service.GetHistory(ctx, 2)
+
+	assert.Nil(suite.T(), history)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.applicationRepositoryMock.AssertNotCalled(suite.T(), "GetHistory", 2)
+}
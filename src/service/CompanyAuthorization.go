@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrForbidden = errors.New("Forbidden")
+
+// companyAuthorizer checks that callers may manage the offers, applications,
+// webhooks and API tokens of companies.
+type companyAuthorizer struct {
+	MemberRepo repository.ICompanyMemberRepository
+	AuditRepo  repository.IAuditRepository
+	Logger     *logrus.Entry
+}
+
+// authorize checks that the caller in ctx may take the action on the company.
+// Platform admins may act on every company and other users on the companies
+// they are members of. API tokens may only create job offers of their own
+// company. Every rejection is audited.
+func (authorizer *companyAuthorizer) authorize(ctx context.Context, action string, companyId int, offerId *int) error {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return authorizer.reject(nil, action, companyId, offerId, "caller is not authenticated")
+	}
+	if identity.IsApiToken() {
+		return authorizer.authorizeApiToken(identity, action, companyId, offerId)
+	}
+	if identity.HasAnyRole(auth.RolePlatformAdmin) {
+		return nil
+	}
+
+	member, err := authorizer.MemberRepo.IsMember(identity.UserID, companyId)
+	if err != nil {
+		authorizer.Logger.Debug(err.Error())
+		return err
+	}
+	if !member {
+		return authorizer.reject(identity, action, companyId, offerId, fmt.Sprintf("user %d is not a member of company %d", identity.UserID, companyId))
+	}
+
+	return nil
+}
+
+func (authorizer *companyAuthorizer) authorizeApiToken(identity *auth.Identity, action string, companyId int, offerId *int) error {
+	switch {
+	case action != model.AuditActionCreate:
+		return authorizer.reject(identity, action, companyId, offerId, fmt.Sprintf("API token %d may only create job offers", identity.TokenID))
+	case !identity.HasScope(auth.ScopeCreateJobOffers):
+		return authorizer.reject(identity, action, companyId, offerId, fmt.Sprintf("API token %d does not have the scope %s", identity.TokenID, auth.ScopeCreateJobOffers))
+	case !identity.IsMemberOf(companyId):
+		return authorizer.reject(identity, action, companyId, offerId, fmt.Sprintf("API token %d belongs to another company", identity.TokenID))
+	}
+	return nil
+}
+
+// reject audits the rejected action and returns the error it is rejected with.
+// The action is rejected even when it can not be audited.
+func (authorizer *companyAuthorizer) reject(identity *auth.Identity, action string, companyId int, offerId *int, reason string) error {
+	err := fmt.Errorf("%w: can not %s for company %d, %s", ErrForbidden, action, companyId, reason)
+	authorizer.Logger.Warn(err.Error())
+
+	entry := model.AuditEntry{CompanyID: companyId, JobOfferID: offerId, Action: action, Reason: reason}
+	if identity != nil && identity.IsApiToken() {
+		entry.ApiTokenID = &identity.TokenID
+	} else if identity != nil {
+		entry.UserID = &identity.UserID
+	}
+	if auditErr := authorizer.AuditRepo.Add(entry); auditErr != nil {
+		authorizer.Logger.Debug(auditErr.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ExpirySweeper periodically expires the published job offers whose valid
+// until date has passed.
+type ExpirySweeper struct {
+	Service  IJobOfferService
+	Interval time.Duration
+	Logger   *logrus.Entry
+}
+
+func (sweeper *ExpirySweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(sweeper.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sweeper.Sweep(time.Now())
+			}
+		}
+	}()
+}
+
+func (sweeper *ExpirySweeper) Sweep(now time.Time) {
+	offers, err := sweeper.Service.ExpireOffers(now)
+	if err != nil {
+		sweeper.Logger.Debug(err.Error())
+		return
+	}
+
+	for _, offer := range offers {
+		sweeper.Logger.Info(fmt.Sprintf("Job offer with id %d expired", offer.ID))
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/events"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type JobAlertService struct {
+	JobAlertRepo repository.IJobAlertRepository
+	Logger       *logrus.Entry
+}
+
+type IJobAlertService interface {
+	GetUsersAlerts(int, bool, dto.PageRequestDTO) (*dto.JobAlertPageDTO, error)
+	Dismiss(int, int) error
+	Publish(context.Context, events.DomainEvent) error
+}
+
+func NewJobAlertService(jobAlertRepository repository.IJobAlertRepository, logger *logrus.Entry) IJobAlertService {
+	return &JobAlertService{
+		jobAlertRepository,
+		logger,
+	}
+}
+
+// GetUsersAlerts returns a page of the alerts of the user, newest first.
+// Dismissed alerts are left out unless includeDismissed is set.
+func (service *JobAlertService) GetUsersAlerts(userId int, includeDismissed bool, pageDTO dto.PageRequestDTO) (*dto.JobAlertPageDTO, error) {
+	page, err := toNewestFirstPageRequest(pageDTO)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting job alerts from database for user %d", userId))
+	alerts, err := service.JobAlertRepo.GetByUser(userId, includeDismissed, page)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got job alerts from database for user %d", userId))
+	return mapper.JobAlertPageToJobAlertPageDTO(alerts), nil
+}
+
+func (service *JobAlertService) Dismiss(userId int, id int) error {
+	service.Logger.Info(fmt.Sprintf("Dismissing job alert with id %d of user %d", id, userId))
+
+	if err := service.JobAlertRepo.Dismiss(userId, id, time.Now()); err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully dismissed job alert with id %d", id))
+	return nil
+}
+
+// Publish queues the offers that were created as published or were published
+// to be matched against the saved searches by the AlertMatcher.
+func (service *JobAlertService) Publish(ctx context.Context, event events.DomainEvent) error {
+	var offerId int
+	switch e := event.(type) {
+	case events.JobOfferCreated:
+		if e.Offer == nil || e.Offer.Status != model.JobOfferStatusPublished {
+			return nil
+		}
+		offerId = e.OfferID
+	case events.JobOfferPublished:
+		offerId = e.OfferID
+	default:
+		return nil
+	}
+
+	if err := service.JobAlertRepo.AddCheck(model.AlertCheck{JobOfferID: offerId, EventID: event.EventID()}); err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Queued job offer with id %d for matching against saved searches", offerId))
+	return nil
+}
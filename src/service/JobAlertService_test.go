@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/events"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobAlertServiceUnitTestsSuite struct {
+	suite.Suite
+	jobAlertRepositoryMock *repository.JobAlertRepositoryMock
+	service                IJobAlertService
+}
+
+func TestJobAlertServiceUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(JobAlertServiceUnitTestsSuite))
+}
+
+func (suite *JobAlertServiceUnitTestsSuite) SetupTest() {
+	suite.jobAlertRepositoryMock = new(repository.JobAlertRepositoryMock)
+	suite.service = NewJobAlertService(suite.jobAlertRepositoryMock, utils.Logger())
+}
+
+func (suite *JobAlertServiceUnitTestsSuite) TestJobAlertService_Publish_QueuesPublishedOffers() {
+	created := events.JobOfferCreated{JobOfferEvent: events.JobOfferEvent{ID: "1", OfferID: 3, Offer: &dto.JobOfferResponseDTO{ID: 3, Status: model.JobOfferStatusPublished}}}
+	published := events.JobOfferPublished{JobOfferEvent: events.JobOfferEvent{ID: "2", OfferID: 4, Offer: &dto.JobOfferResponseDTO{ID: 4, Status: model.JobOfferStatusPublished}}}
+	suite.jobAlertRepositoryMock.On("AddCheck", model.AlertCheck{JobOfferID: 3, EventID: "1"}).Return(nil).Once()
+	suite.jobAlertRepositoryMock.On("AddCheck", model.AlertCheck{JobOfferID: 4, EventID: "2"}).Return(nil).Once()
+
+	assert.Nil(suite.T(), suite.service.Publish(context.Background(), created))
+	assert.Nil(suite.T(), suite.service.Publish(context.Background(), published))
+	suite.jobAlertRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *JobAlertServiceUnitTestsSuite) TestJobAlertService_Publish_IgnoresOtherEvents() {
+	draft := events.JobOfferCreated{JobOfferEvent: events.JobOfferEvent{ID: "1", OfferID: 3, Offer: &dto.JobOfferResponseDTO{ID: 3, Status: model.JobOfferStatusDraft}}}
+	updated := events.JobOfferUpdated{JobOfferEvent: events.JobOfferEvent{ID: "2", OfferID: 3}}
+
+	assert.Nil(suite.T(), suite.service.Publish(context.Background(), draft))
+	assert.Nil(suite.T(), suite.service.Publish(context.Background(), updated))
+	suite.jobAlertRepositoryMock.AssertNotCalled(suite.T(), "AddCheck", mock.Anything)
+}
+
+func (suite *JobAlertServiceUnitTestsSuite) TestJobAlertService_GetUsersAlerts_LeavesOutDismissed() {
+	page := repository.PageRequest{Limit: defaultPageLimit, SortField: repository.SortByID, Descending: true}
+	suite.jobAlertRepositoryMock.On("GetByUser", 5, false, page).Return(&repository.JobAlertPage{Alerts: []*model.JobAlert{
+		{ID: 2, SavedSearchID: 1, SavedSearch: model.SavedSearch{ID: 1, Name: "Go jobs"}, JobOfferID: 3, JobOffer: model.JobOffer{ID: 3, Position: "Developer"}},
+	}}, nil).Once()
+
+	alerts, err := suite.service.GetUsersAlerts(5, false, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(alerts.Items))
+	assert.Equal(suite.T(), "Go jobs", alerts.Items[0].SavedSearchName)
+	assert.Equal(suite.T(), "Developer", alerts.Items[0].JobOffer.Position)
+}
+
+func (suite *JobAlertServiceUnitTestsSuite) TestJobAlertService_Dismiss_NotFound() {
+	suite.jobAlertRepositoryMock.On("Dismiss", 5, 2, mock.AnythingOfType("time.Time")).Return(repository.ErrJobAlertNotFound).Once()
+
+	err := suite.service.Dismiss(5, 2)
+
+	assert.True(suite.T(), errors.Is(err, repository.ErrJobAlertNotFound))
+}
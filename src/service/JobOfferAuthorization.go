@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/model"
+)
+
+// authorize checks that the caller in ctx may manage the offers of the
+// company, see companyAuthorizer.
+func (service *JobOfferService) authorize(ctx context.Context, action string, companyId int, offerId *int) error {
+	authorizer := companyAuthorizer{MemberRepo: service.MemberRepo, AuditRepo: service.AuditRepo, Logger: service.Logger}
+	return authorizer.authorize(ctx, action, companyId, offerId)
+}
+
+// changedBy names the caller in ctx for the revisions of the offers it changes.
+func changedBy(ctx context.Context) string {
+	if identity, ok := auth.FromContext(ctx); ok {
+		return identity.Subject()
+	}
+	return model.RevisionChangedBySystem
+}
+
+// getOwnedOffer returns the offer when the caller in ctx may manage it.
+func (service *JobOfferService) getOwnedOffer(ctx context.Context, action string, id int) (*model.JobOffer, error) {
+	offer, err := service.JobOfferRepo.GetById(id)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if err := service.authorize(ctx, action, offer.CompanyID, &id); err != nil {
+		return nil, err
+	}
+	return offer, nil
+}
+
+// authorizeOffer checks that the caller in ctx may manage the offer, which can
+// be in the trash.
+func (service *JobOfferService) authorizeOffer(ctx context.Context, action string, id int) error {
+	companyId, err := service.JobOfferRepo.GetCompanyID(id)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	return service.authorize(ctx, action, companyId, &id)
+}
@@ -1,43 +1,111 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"jobs-ms/src/dto"
 	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
 	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+
+	// maxRecommendationCandidates bounds how many of the offers requiring
+	// the most of the skills of a candidate are scored.
+	maxRecommendationCandidates = 500
+
+	// maxSearchRadiusKm is about half the circumference of the earth.
+	maxSearchRadiusKm = 20000
+)
+
+var ErrInvalidPageRequest = errors.New("Invalid page request")
+var ErrInvalidSearchFilter = errors.New("Invalid search filter")
+var ErrInvalidStatusTransition = errors.New("Invalid job offer status transition")
+var ErrInvalidLocale = errors.New("Invalid locale")
+
+// jobOfferTransitions lists the statuses every status can move to.
+var jobOfferTransitions = map[string][]string{
+	model.JobOfferStatusDraft:     {model.JobOfferStatusPublished},
+	model.JobOfferStatusPublished: {model.JobOfferStatusClosed, model.JobOfferStatusExpired},
+	model.JobOfferStatusClosed:    {model.JobOfferStatusPublished},
+	model.JobOfferStatusExpired:   {model.JobOfferStatusPublished},
+}
+
+// publicJobOfferStatuses lists the statuses of the offers anyone can see.
+var publicJobOfferStatuses = map[string]bool{
+	model.JobOfferStatusPublished: true,
+	model.JobOfferStatusClosed:    true,
+	model.JobOfferStatusExpired:   true,
+}
+
+// JobOfferService changes offers on behalf of the caller in the context of
+// a request, who has to be allowed to manage the offers of the company.
 type JobOfferService struct {
 	JobOfferRepo repository.IJobOfferRepository
+	MemberRepo   repository.ICompanyMemberRepository
+	AuditRepo    repository.IAuditRepository
 	Logger       *logrus.Entry
 }
 
 type IJobOfferService interface {
-	Add(*dto.JobOfferRequestDTO) (*dto.JobOfferResponseDTO, error)
-	GetCompanysOffers(int) ([]*dto.JobOfferResponseDTO, error)
-	GetAll() ([]*dto.JobOfferResponseDTO, error)
-	Search(string) ([]*dto.JobOfferResponseDTO, error)
-	GetById(int) (*dto.JobOfferResponseDTO, error)
-	Delete(int) error
+	Add(context.Context, *dto.JobOfferRequestDTO) (*dto.JobOfferResponseDTO, error)
+	Update(context.Context, int, *dto.JobOfferRequestDTO) (*dto.JobOfferResponseDTO, error)
+	Patch(context.Context, int, []byte) (*dto.JobOfferResponseDTO, error)
+	Publish(context.Context, int) (*dto.JobOfferResponseDTO, error)
+	Close(context.Context, int) (*dto.JobOfferResponseDTO, error)
+	ExpireOffers(time.Time) ([]*dto.JobOfferResponseDTO, error)
+	GetCompanysOffers(int, map[string][]string, dto.PageRequestDTO) (*dto.JobOfferPageDTO, error)
+	GetAll(map[string][]string, dto.PageRequestDTO) (*dto.JobOfferPageDTO, error)
+	Search(map[string][]string, dto.PageRequestDTO) (*dto.JobOfferPageDTO, error)
+	Recommend(*dto.RecommendationRequestDTO) ([]*dto.RecommendationResponseDTO, error)
+	GetById(context.Context, int, string) (*dto.JobOfferResponseDTO, error)
+	SaveTranslation(context.Context, int, string, *dto.JobOfferTranslationRequestDTO) (*dto.JobOfferTranslationResponseDTO, error)
+	Delete(context.Context, int) error
+	GetTrash(context.Context, int) ([]*dto.JobOfferResponseDTO, error)
+	Restore(context.Context, int) (*dto.JobOfferResponseDTO, error)
+	PurgeTrash(time.Time) (int64, error)
+	GetRevisions(context.Context, int) ([]*dto.JobOfferRevisionResponseDTO, error)
+	RevertToRevision(context.Context, int, int) (*dto.JobOfferResponseDTO, error)
 }
 
-func NewJobOfferService(jobOfferRepository repository.IJobOfferRepository, logger *logrus.Entry) IJobOfferService {
+func NewJobOfferService(jobOfferRepository repository.IJobOfferRepository, memberRepository repository.ICompanyMemberRepository, auditRepository repository.IAuditRepository, logger *logrus.Entry) IJobOfferService {
 	return &JobOfferService{
 		jobOfferRepository,
+		memberRepository,
+		auditRepository,
 		logger,
 	}
 }
 
-func (service *JobOfferService) Add(dto *dto.JobOfferRequestDTO) (*dto.JobOfferResponseDTO, error) {
+func (service *JobOfferService) Add(ctx context.Context, dto *dto.JobOfferRequestDTO) (*dto.JobOfferResponseDTO, error) {
 	err := dto.Validate()
 	if err != nil {
 		service.Logger.Debug(err.Error())
 		return nil, err
 	}
 
+	if err := service.authorize(ctx, model.AuditActionCreate, dto.CompanyID, nil); err != nil {
+		return nil, err
+	}
+
 	entity := mapper.JobOfferRequestDTOToJobOffer(dto)
+	entity.UpdatedBy = changedBy(ctx)
+	if entity.Status == "" {
+		entity.Status = model.JobOfferStatusPublished
+	}
 
 	service.Logger.Info("Adding new job offer in database")
 
@@ -51,61 +119,289 @@ func (service *JobOfferService) Add(dto *dto.JobOfferRequestDTO) (*dto.JobOfferR
 	return mapper.JobOfferToJobOfferResponseDTO(&addedEntity), nil
 }
 
-func (service *JobOfferService) GetCompanysOffers(id int) ([]*dto.JobOfferResponseDTO, error) {
+func (service *JobOfferService) Update(ctx context.Context, id int, dto *dto.JobOfferRequestDTO) (*dto.JobOfferResponseDTO, error) {
+	err := dto.Validate()
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	existing, err := service.getOwnedOffer(ctx, model.AuditActionUpdate, id)
+	if err != nil {
+		return nil, err
+	}
+	if dto.CompanyID != existing.CompanyID {
+		if err := service.authorize(ctx, model.AuditActionUpdate, dto.CompanyID, &id); err != nil {
+			return nil, err
+		}
+	}
+
+	entity := mapper.JobOfferRequestDTOToJobOffer(dto)
+	entity.ID = id
+	entity.CreatedAt = existing.CreatedAt
+	entity.UpdatedBy = changedBy(ctx)
+
+	service.Logger.Info(fmt.Sprintf("Updating job offer in database with id %d", id))
+
+	updatedEntity, err := service.JobOfferRepo.Update(*entity)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully updated job offer in database with id %d", id))
+	return mapper.JobOfferToJobOfferResponseDTO(&updatedEntity), nil
+}
+
+func (service *JobOfferService) Patch(ctx context.Context, id int, patch []byte) (*dto.JobOfferResponseDTO, error) {
+	offer, err := service.getOwnedOffer(ctx, model.AuditActionUpdate, id)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := json.Marshal(mapper.JobOfferToJobOfferRequestDTO(offer))
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	patched, err := utils.MergePatch(original, patch)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	var requestDTO dto.JobOfferRequestDTO
+	if err := json.Unmarshal(patched, &requestDTO); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	return service.Update(ctx, id, &requestDTO)
+}
+
+func (service *JobOfferService) Publish(ctx context.Context, id int) (*dto.JobOfferResponseDTO, error) {
+	offer, err := service.getOwnedOffer(ctx, model.AuditActionPublish, id)
+	if err != nil {
+		return nil, err
+	}
+	return service.changeStatus(offer, model.JobOfferStatusPublished, changedBy(ctx))
+}
+
+func (service *JobOfferService) Close(ctx context.Context, id int) (*dto.JobOfferResponseDTO, error) {
+	offer, err := service.getOwnedOffer(ctx, model.AuditActionClose, id)
+	if err != nil {
+		return nil, err
+	}
+	return service.changeStatus(offer, model.JobOfferStatusClosed, changedBy(ctx))
+}
+
+func (service *JobOfferService) changeStatus(offer *model.JobOffer, status string, changedBy string) (*dto.JobOfferResponseDTO, error) {
+	id := offer.ID
+
+	if !canTransition(offer.Status, status) {
+		err := fmt.Errorf("%w: job offer with id %d can not move from %s to %s", ErrInvalidStatusTransition, id, offer.Status, status)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if status == model.JobOfferStatusPublished && offer.ValidUntil != nil && !offer.ValidUntil.After(time.Now()) {
+		err := fmt.Errorf("%w: job offer with id %d is valid only until %s", ErrInvalidStatusTransition, id, offer.ValidUntil.Format(time.RFC3339))
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Changing status of job offer with id %d to %s", id, status))
+
+	updatedOffer, err := service.JobOfferRepo.UpdateStatus(id, offer.Status, status, changedBy)
+	if errors.Is(err, repository.ErrJobOfferStatusConflict) {
+		err = fmt.Errorf("%w: job offer with id %d is no longer %s", ErrInvalidStatusTransition, id, offer.Status)
+	}
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully changed status of job offer with id %d to %s", id, status))
+	return mapper.JobOfferToJobOfferResponseDTO(updatedOffer), nil
+}
+
+// ExpireOffers moves every published offer whose valid until date is not
+// after now to the expired status and returns the expired offers.
+func (service *JobOfferService) ExpireOffers(now time.Time) ([]*dto.JobOfferResponseDTO, error) {
+	service.Logger.Info("Getting expired job offers from database")
+	offers, err := service.JobOfferRepo.GetExpired(now)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	res := []*dto.JobOfferResponseDTO{}
+	for _, offer := range offers {
+		expiredOffer, err := service.changeStatus(offer, model.JobOfferStatusExpired, model.RevisionChangedBySystem)
+		if err != nil {
+			continue
+		}
+		res = append(res, expiredOffer)
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully expired %d job offers", len(res)))
+	return res, nil
+}
+
+func canTransition(from string, to string) bool {
+	for _, status := range jobOfferTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (service *JobOfferService) GetCompanysOffers(id int, filters map[string][]string, pageDTO dto.PageRequestDTO) (*dto.JobOfferPageDTO, error) {
+	page, err := toPageRequest(pageDTO, false)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	filter, err := toListFilter(filters)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
 	service.Logger.Info(fmt.Sprintf("Getting job offers from database for company %d", id))
-	offers, err := service.JobOfferRepo.GetByCompany(id)
+	offers, err := service.JobOfferRepo.GetByCompany(id, filter, page)
 
 	if err != nil {
 		service.Logger.Debug(err.Error())
 		return nil, err
 	}
 
-	res := make([]*dto.JobOfferResponseDTO, len(offers))
-	for i := 0; i < len(offers); i++ {
-		res[i] = mapper.JobOfferToJobOfferResponseDTO(offers[i])
+	offersDTO := mapper.JobOfferPageToJobOfferPageDTO(offers)
+	if err := service.translate(offersDTO.Items, filter.Locale); err != nil {
+		return nil, err
 	}
 
 	service.Logger.Info(fmt.Sprintf("Successfully got job offers from database for company %d", id))
-	return res, nil
+	return offersDTO, nil
 }
 
-func (service *JobOfferService) GetAll() ([]*dto.JobOfferResponseDTO, error) {
-	service.Logger.Info("Getting job offers from database for company ")
-	offers, err := service.JobOfferRepo.GetAll()
+func (service *JobOfferService) GetAll(filters map[string][]string, pageDTO dto.PageRequestDTO) (*dto.JobOfferPageDTO, error) {
+	page, err := toPageRequest(pageDTO, false)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
 
+	filter, err := toListFilter(filters)
 	if err != nil {
 		service.Logger.Debug(err.Error())
 		return nil, err
 	}
 
-	res := make([]*dto.JobOfferResponseDTO, len(offers))
-	for i := 0; i < len(offers); i++ {
-		res[i] = mapper.JobOfferToJobOfferResponseDTO(offers[i])
+	service.Logger.Info("Getting job offers from database")
+	offers, err := service.JobOfferRepo.GetAll(filter, page)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	offersDTO := mapper.JobOfferPageToJobOfferPageDTO(offers)
+	if err := service.translate(offersDTO.Items, filter.Locale); err != nil {
+		return nil, err
 	}
 
 	service.Logger.Info("Successfully got job offers from database")
-	return res, nil
+	return offersDTO, nil
 }
 
-func (service *JobOfferService) Search(param string) ([]*dto.JobOfferResponseDTO, error) {
-	service.Logger.Info(fmt.Sprintf("Searching job offers from database for param %s", param))
-	offers, err := service.JobOfferRepo.Search(param)
+func (service *JobOfferService) Search(filters map[string][]string, pageDTO dto.PageRequestDTO) (*dto.JobOfferPageDTO, error) {
+	filter, err := toSearchFilter(filters)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
 
+	page, err := toPageRequest(pageDTO, filter.Text != "")
 	if err != nil {
 		service.Logger.Debug(err.Error())
 		return nil, err
 	}
 
-	res := make([]*dto.JobOfferResponseDTO, len(offers))
-	for i := 0; i < len(offers); i++ {
-		res[i] = mapper.JobOfferToJobOfferResponseDTO(offers[i])
+	service.Logger.Info(fmt.Sprintf("Searching job offers from database for filters %v", filters))
+	offers, err := service.JobOfferRepo.Search(filter, page)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
 	}
 
-	service.Logger.Info(fmt.Sprintf("Successfully searched job offers from database by param %s", param))
-	return res, nil
+	offersDTO := mapper.JobOfferPageToJobOfferPageDTO(offers)
+	if err := service.translate(offersDTO.Items, filter.Locale); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully searched job offers from database by filters %v", filters))
+	return offersDTO, nil
 }
 
-func (service *JobOfferService) GetById(id int) (*dto.JobOfferResponseDTO, error) {
+// Recommend ranks the published offers that require any of the skills of the
+// candidate by their match score, best first and newest first among offers
+// with the same score. Only the maxRecommendationCandidates offers that
+// require the most of the skills are scored.
+func (service *JobOfferService) Recommend(recommendationDTO *dto.RecommendationRequestDTO) ([]*dto.RecommendationResponseDTO, error) {
+	if err := recommendationDTO.Validate(); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	profile := newCandidateProfile(recommendationDTO)
+	filter := repository.SearchFilter{
+		Statuses:           []string{model.JobOfferStatusPublished},
+		Skills:             profile.Skills,
+		RankBySkills:       true,
+		ExcludedCompanyIDs: recommendationDTO.ExcludedCompanies,
+	}
+	page := repository.PageRequest{Limit: maxRecommendationCandidates, SortField: repository.SortByRelevance, Descending: true}
+
+	service.Logger.Info(fmt.Sprintf("Getting job offers from database to recommend for skills %v", profile.Skills))
+	offers, err := service.JobOfferRepo.Search(filter, page)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	recommendations := make([]*dto.RecommendationResponseDTO, len(offers.Offers))
+	for i, offer := range offers.Offers {
+		recommendations[i] = scoreOffer(profile, offer)
+	}
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Offer.ID > recommendations[j].Offer.ID
+	})
+
+	limit := recommendationDTO.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully recommended %d job offers", len(recommendations)))
+	return recommendations, nil
+}
+
+// GetById returns the offer translated to the locale when it has a
+// translation to it, and in the default locale otherwise. Offers that were
+// never published are found only by the callers that may manage them.
+func (service *JobOfferService) GetById(ctx context.Context, id int, locale string) (*dto.JobOfferResponseDTO, error) {
 	service.Logger.Info(fmt.Sprintf("Getting job offer from database with id %d", id))
 	offer, err := service.JobOfferRepo.GetById(id)
 
@@ -114,14 +410,100 @@ func (service *JobOfferService) GetById(id int) (*dto.JobOfferResponseDTO, error
 		return nil, err
 	}
 
-	dto := mapper.JobOfferToJobOfferResponseDTO(offer)
+	if !publicJobOfferStatuses[offer.Status] {
+		if err := service.authorize(ctx, model.AuditActionView, offer.CompanyID, &id); errors.Is(err, ErrForbidden) {
+			return nil, fmt.Errorf("%w: job offer %d is %s", repository.ErrJobOfferNotFound, id, offer.Status)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	offerDTO := mapper.JobOfferToJobOfferResponseDTO(offer)
+	if err := service.translate([]*dto.JobOfferResponseDTO{offerDTO}, locale); err != nil {
+		return nil, err
+	}
+
 	service.Logger.Info(fmt.Sprintf("Successfully got job offer from database with id %d", id))
-	return dto, nil
+	return offerDTO, nil
 }
 
-func (service *JobOfferService) Delete(id int) error {
+// SaveTranslation creates or replaces the translation of the offer to the
+// locale, which has to be one of the supported locales other than the default.
+func (service *JobOfferService) SaveTranslation(ctx context.Context, id int, locale string, translationDTO *dto.JobOfferTranslationRequestDTO) (*dto.JobOfferTranslationResponseDTO, error) {
+	locale = utils.NormalizeLocale(locale)
+	if !utils.IsSupportedLocale(locale) {
+		err := fmt.Errorf("%w: %s is not one of the supported locales %s", ErrInvalidLocale, locale, strings.Join(utils.SupportedLocales, ", "))
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+	if locale == utils.DefaultLocale {
+		err := fmt.Errorf("%w: %s is the default locale, update the job offer itself", ErrInvalidLocale, locale)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if err := translationDTO.Validate(); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if err := service.authorizeOffer(ctx, model.AuditActionTranslate, id); err != nil {
+		return nil, err
+	}
+
+	translation := mapper.JobOfferTranslationRequestDTOToJobOfferTranslation(id, locale, translationDTO)
+
+	service.Logger.Info(fmt.Sprintf("Saving %s translation of job offer with id %d", locale, id))
+	savedTranslation, err := service.JobOfferRepo.SaveTranslation(*translation)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully saved %s translation of job offer with id %d", locale, id))
+	return mapper.JobOfferTranslationToJobOfferTranslationResponseDTO(&savedTranslation), nil
+}
+
+// translate replaces the text fields of the offers with their translations
+// to the locale. Offers without a translation stay in the default locale.
+func (service *JobOfferService) translate(offers []*dto.JobOfferResponseDTO, locale string) error {
+	if locale == "" || locale == utils.DefaultLocale || len(offers) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(offers))
+	for i, offer := range offers {
+		ids[i] = offer.ID
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting %s translations of job offers from database", locale))
+	translations, err := service.JobOfferRepo.GetTranslations(ids, locale)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	byOffer := make(map[int]*model.JobOfferTranslation, len(translations))
+	for _, translation := range translations {
+		byOffer[translation.JobOfferID] = translation
+	}
+	for _, offer := range offers {
+		if translation, ok := byOffer[offer.ID]; ok {
+			mapper.TranslateJobOfferResponseDTO(offer, translation)
+		}
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully translated %d job offers to %s", len(byOffer), locale))
+	return nil
+}
+
+func (service *JobOfferService) Delete(ctx context.Context, id int) error {
+	if err := service.authorizeOffer(ctx, model.AuditActionDelete, id); err != nil {
+		return err
+	}
+
 	service.Logger.Info(fmt.Sprintf("Deleting job offer from database with id %d", id))
-	err := service.JobOfferRepo.Delete(id)
+	err := service.JobOfferRepo.Delete(id, changedBy(ctx))
 
 	if err != nil {
 		service.Logger.Debug(err.Error())
@@ -131,3 +513,490 @@ func (service *JobOfferService) Delete(id int) error {
 	service.Logger.Info(fmt.Sprintf("Successfully deleted job offer from database with id %d", id))
 	return nil
 }
+
+func (service *JobOfferService) GetTrash(ctx context.Context, companyId int) ([]*dto.JobOfferResponseDTO, error) {
+	if err := service.authorize(ctx, model.AuditActionListTrash, companyId, nil); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting deleted job offers from database for company %d", companyId))
+	offers, err := service.JobOfferRepo.GetTrash(companyId)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	res := make([]*dto.JobOfferResponseDTO, len(offers))
+	for i := 0; i < len(offers); i++ {
+		res[i] = mapper.JobOfferToJobOfferResponseDTO(offers[i])
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got deleted job offers from database for company %d", companyId))
+	return res, nil
+}
+
+func (service *JobOfferService) Restore(ctx context.Context, id int) (*dto.JobOfferResponseDTO, error) {
+	if err := service.authorizeOffer(ctx, model.AuditActionRestore, id); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Restoring job offer in database with id %d", id))
+	offer, err := service.JobOfferRepo.Restore(id, changedBy(ctx))
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully restored job offer in database with id %d", id))
+	return mapper.JobOfferToJobOfferResponseDTO(offer), nil
+}
+
+// PurgeTrash permanently removes the offers deleted before the given time.
+func (service *JobOfferService) PurgeTrash(before time.Time) (int64, error) {
+	service.Logger.Info(fmt.Sprintf("Purging job offers deleted before %s", before.Format(time.RFC3339)))
+	purged, err := service.JobOfferRepo.Purge(before)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return 0, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully purged %d job offers", purged))
+	return purged, nil
+}
+
+// GetRevisions returns the revisions of the offer from the oldest one, each
+// with the fields it changed compared to the previous revision, when the
+// caller in ctx may manage the offer.
+func (service *JobOfferService) GetRevisions(ctx context.Context, id int) ([]*dto.JobOfferRevisionResponseDTO, error) {
+	if err := service.authorizeOffer(ctx, model.AuditActionListRevisions, id); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting revisions from database for job offer with id %d", id))
+	revisions, err := service.JobOfferRepo.GetRevisions(id)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		err := fmt.Errorf("%w: job offer with id %d has no revisions", repository.ErrJobOfferNotFound, id)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	res := make([]*dto.JobOfferRevisionResponseDTO, len(revisions))
+	previous := map[string]interface{}{}
+	for i, revision := range revisions {
+		current, err := revisionFields(revision)
+		if err != nil {
+			service.Logger.Debug(err.Error())
+			return nil, err
+		}
+
+		res[i] = mapper.JobOfferRevisionToJobOfferRevisionResponseDTO(revision, diffFields(previous, current))
+		previous = current
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got revisions from database for job offer with id %d", id))
+	return res, nil
+}
+
+// RevertToRevision restores the content of the offer saved in the given
+// revision. The status of the offer is left as it is, since it can only be
+// changed through its transitions.
+func (service *JobOfferService) RevertToRevision(ctx context.Context, id int, revision int) (*dto.JobOfferResponseDTO, error) {
+	existing, err := service.getOwnedOffer(ctx, model.AuditActionRevert, id)
+	if err != nil {
+		return nil, err
+	}
+
+	jobOfferRevision, err := service.JobOfferRepo.GetRevision(id, revision)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	var snapshot model.JobOffer
+	if err := json.Unmarshal([]byte(jobOfferRevision.Snapshot), &snapshot); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	requestDTO := mapper.JobOfferToJobOfferRequestDTO(&snapshot)
+	if err := requestDTO.Validate(); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+	if snapshot.CompanyID != existing.CompanyID {
+		if err := service.authorize(ctx, model.AuditActionRevert, snapshot.CompanyID, &id); err != nil {
+			return nil, err
+		}
+	}
+
+	entity := mapper.JobOfferRequestDTOToJobOffer(requestDTO)
+	entity.ID = id
+	entity.CreatedAt = existing.CreatedAt
+	entity.UpdatedBy = changedBy(ctx)
+
+	service.Logger.Info(fmt.Sprintf("Reverting job offer with id %d to revision %d", id, revision))
+
+	revertedEntity, err := service.JobOfferRepo.Revert(*entity, revision)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully reverted job offer with id %d to revision %d", id, revision))
+	return mapper.JobOfferToJobOfferResponseDTO(&revertedEntity), nil
+}
+
+// revisionFields returns the fields of the offer saved in the revision as
+// they appear in responses, leaving out the ones that never change.
+func revisionFields(revision *model.JobOfferRevision) (map[string]interface{}, error) {
+	var snapshot model.JobOffer
+	if err := json.Unmarshal([]byte(revision.Snapshot), &snapshot); err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(mapper.JobOfferToJobOfferResponseDTO(&snapshot))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range []string{"ID", "CreatedAt", "Relevance"} {
+		delete(fields, field)
+	}
+	return fields, nil
+}
+
+func diffFields(previous map[string]interface{}, current map[string]interface{}) []dto.FieldChangeDTO {
+	names := []string{}
+	for name := range current {
+		names = append(names, name)
+	}
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := []dto.FieldChangeDTO{}
+	for _, name := range names {
+		if !reflect.DeepEqual(previous[name], current[name]) {
+			changes = append(changes, dto.FieldChangeDTO{Field: name, OldValue: previous[name], NewValue: current[name]})
+		}
+	}
+	return changes
+}
+
+// toPageRequest validates the requested page. Ranked pages are sorted by
+// descending relevance unless another sort is requested.
+func toPageRequest(pageDTO dto.PageRequestDTO, ranked bool) (repository.PageRequest, error) {
+	page := repository.PageRequest{
+		Limit:     pageDTO.Limit,
+		SortField: repository.SortByID,
+		WithTotal: pageDTO.IncludeTotal,
+	}
+	if ranked {
+		page.SortField = repository.SortByRelevance
+		page.Descending = true
+	}
+
+	if page.Limit == 0 {
+		page.Limit = defaultPageLimit
+	}
+	if page.Limit < 0 || page.Limit > maxPageLimit {
+		return page, fmt.Errorf("%w: limit should be between 1 and %d", ErrInvalidPageRequest, maxPageLimit)
+	}
+
+	if pageDTO.Sort != "" {
+		page.Descending = strings.HasPrefix(pageDTO.Sort, "-")
+		page.SortField = strings.TrimPrefix(pageDTO.Sort, "-")
+		if !repository.IsSortField(page.SortField) && !(ranked && page.SortField == repository.SortByRelevance) {
+			return page, fmt.Errorf("%w: unknown sort field %s", ErrInvalidPageRequest, page.SortField)
+		}
+	}
+
+	if pageDTO.Cursor != "" {
+		cursor, err := repository.DecodeCursor(pageDTO.Cursor)
+		if err != nil {
+			return page, fmt.Errorf("%w: %s", ErrInvalidPageRequest, err.Error())
+		}
+		if cursor.SortField != page.SortField || cursor.Descending != page.Descending {
+			return page, fmt.Errorf("%w: cursor does not match requested sort", ErrInvalidPageRequest)
+		}
+		page.After = cursor
+	}
+
+	return page, nil
+}
+
+func toSearchFilter(filters map[string][]string) (repository.SearchFilter, error) {
+	filter := repository.SearchFilter{Statuses: []string{model.JobOfferStatusPublished}}
+
+	for name, values := range filters {
+		if len(values) != 1 && name != "skills" {
+			return filter, fmt.Errorf("%w: filter %s should be given once", ErrInvalidSearchFilter, name)
+		}
+
+		switch name {
+		case "company_id":
+			companyID, err := strconv.Atoi(values[0])
+			if err != nil {
+				return filter, fmt.Errorf("%w: company_id should be a number", ErrInvalidSearchFilter)
+			}
+			filter.CompanyID = &companyID
+		case "position":
+			filter.Position = strings.TrimSpace(values[0])
+		case "skills":
+			for _, value := range values {
+				filter.Skills = append(filter.Skills, splitList(value)...)
+			}
+			filter.Skills = utils.NormalizeSkills(filter.Skills)
+		case "skills_match":
+			switch values[0] {
+			case "any":
+				filter.MatchAllSkills = false
+			case "all":
+				filter.MatchAllSkills = true
+			default:
+				return filter, fmt.Errorf("%w: skills_match should be any or all", ErrInvalidSearchFilter)
+			}
+		case "salary_at_least", "salary_currency", "salary_period":
+			if _, ok := filters["salary_at_least"]; !ok {
+				return filter, fmt.Errorf("%w: %s is only used with salary_at_least", ErrInvalidSearchFilter, name)
+			}
+			if name != "salary_at_least" {
+				continue
+			}
+			salary, err := toSalaryFilter(values[0], filters["salary_currency"], filters["salary_period"])
+			if err != nil {
+				return filter, err
+			}
+			filter.SalaryAtLeast = &salary
+		case "near", "radius_km":
+			if len(filters["near"]) == 0 || len(filters["radius_km"]) == 0 {
+				return filter, fmt.Errorf("%w: near and radius_km should be given together", ErrInvalidSearchFilter)
+			}
+			if name != "near" {
+				continue
+			}
+			near, err := toNearFilter(values[0], filters["radius_km"][0])
+			if err != nil {
+				return filter, err
+			}
+			filter.Near = near
+		case "country":
+			country := strings.ToUpper(strings.TrimSpace(values[0]))
+			if !isCountryCode(country) {
+				return filter, fmt.Errorf("%w: country should be a two letter country code", ErrInvalidSearchFilter)
+			}
+			filter.Country = country
+		case "remote_policy":
+			policies, err := toEnumFilter(name, values[0], model.RemotePolicies)
+			if err != nil {
+				return filter, err
+			}
+			filter.RemotePolicies = policies
+		case "employment_type":
+			types, err := toEnumFilter(name, values[0], model.EmploymentTypes)
+			if err != nil {
+				return filter, err
+			}
+			filter.EmploymentTypes = types
+		case "seniority":
+			levels, err := toEnumFilter(name, values[0], model.SeniorityLevels)
+			if err != nil {
+				return filter, err
+			}
+			filter.SeniorityLevels = levels
+		case "work_schedule":
+			schedules, err := toEnumFilter(name, values[0], model.WorkSchedules)
+			if err != nil {
+				return filter, err
+			}
+			filter.WorkSchedules = schedules
+		case "lang":
+			if err := setLocale(&filter, values[0]); err != nil {
+				return filter, err
+			}
+		case "q", "param":
+			if len(filters["q"]) > 0 && len(filters["param"]) > 0 {
+				return filter, fmt.Errorf("%w: q and param should not be given together", ErrInvalidSearchFilter)
+			}
+			filter.Text = strings.TrimSpace(values[0])
+		case "include_expired":
+			if err := setIncludeExpired(&filter, values[0]); err != nil {
+				return filter, err
+			}
+		case "fields":
+			for _, field := range splitList(values[0]) {
+				if !repository.IsSearchField(field) {
+					return filter, fmt.Errorf("%w: unknown search field %s", ErrInvalidSearchFilter, field)
+				}
+				filter.TextFields = append(filter.TextFields, field)
+			}
+		default:
+			return filter, fmt.Errorf("%w: unknown filter %s", ErrInvalidSearchFilter, name)
+		}
+	}
+
+	return filter, nil
+}
+
+// toListFilter validates the filters of a plain listing, where every given
+// skill has to be required by an offer. Like searches, listings only contain
+// published offers that have not expired unless include_expired is given.
+func toListFilter(filters map[string][]string) (repository.SearchFilter, error) {
+	filter := repository.SearchFilter{Statuses: []string{model.JobOfferStatusPublished}, MatchAllSkills: true}
+
+	for name, values := range filters {
+		switch name {
+		case "skill":
+			filter.Skills = utils.NormalizeSkills(values)
+		case "include_expired", "lang":
+			if len(values) != 1 {
+				return filter, fmt.Errorf("%w: filter %s should be given once", ErrInvalidSearchFilter, name)
+			}
+			setFilter := setIncludeExpired
+			if name == "lang" {
+				setFilter = setLocale
+			}
+			if err := setFilter(&filter, values[0]); err != nil {
+				return filter, err
+			}
+		default:
+			return filter, fmt.Errorf("%w: unknown filter %s", ErrInvalidSearchFilter, name)
+		}
+	}
+
+	return filter, nil
+}
+
+// toSalaryFilter converts the wanted salary to a yearly one in the base
+// currency. The salary is yearly and in the base currency unless the currency
+// or the period is given.
+func toSalaryFilter(amount string, currency []string, period []string) (float64, error) {
+	salary, err := strconv.ParseFloat(amount, 64)
+	if err != nil || salary < 0 {
+		return 0, fmt.Errorf("%w: salary_at_least should be a positive number", ErrInvalidSearchFilter)
+	}
+
+	salaryCurrency, salaryPeriod := utils.BaseCurrency, utils.SalaryPeriodYearly
+	if len(currency) > 0 {
+		salaryCurrency = strings.ToUpper(currency[0])
+	}
+	if len(period) > 0 {
+		salaryPeriod = period[0]
+	}
+	if _, ok := utils.SalaryPeriodsPerYear[salaryPeriod]; !ok {
+		return 0, fmt.Errorf("%w: salary_period should be hourly, monthly or yearly", ErrInvalidSearchFilter)
+	}
+
+	yearly, ok := utils.ToYearlyBaseSalary(salary, salaryCurrency, salaryPeriod)
+	if !ok {
+		return 0, fmt.Errorf("%w: no rate for currency %s", ErrInvalidSearchFilter, salaryCurrency)
+	}
+	return yearly, nil
+}
+
+// toNearFilter parses the point given as latitude,longitude and the radius
+// around it in kilometers.
+func toNearFilter(point string, radius string) (*repository.NearFilter, error) {
+	coordinates := strings.Split(point, ",")
+	if len(coordinates) != 2 {
+		return nil, fmt.Errorf("%w: near should be given as latitude,longitude", ErrInvalidSearchFilter)
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(coordinates[0]), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return nil, fmt.Errorf("%w: near latitude should be between -90 and 90", ErrInvalidSearchFilter)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(coordinates[1]), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return nil, fmt.Errorf("%w: near longitude should be between -180 and 180", ErrInvalidSearchFilter)
+	}
+
+	radiusKm, err := strconv.ParseFloat(radius, 64)
+	if err != nil || radiusKm <= 0 || radiusKm > maxSearchRadiusKm {
+		return nil, fmt.Errorf("%w: radius_km should be a positive number up to %d", ErrInvalidSearchFilter, maxSearchRadiusKm)
+	}
+
+	return &repository.NearFilter{
+		Point:    repository.GeoPoint{Latitude: latitude, Longitude: longitude},
+		RadiusKm: radiusKm,
+	}, nil
+}
+
+// toEnumFilter parses a comma separated list of values of the named filter,
+// each of which has to be one of the allowed ones.
+func toEnumFilter(name string, value string, allowed []string) ([]string, error) {
+	values := splitList(value)
+	for _, item := range values {
+		known := false
+		for _, allowedValue := range allowed {
+			known = known || item == allowedValue
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: %s should be one of %s", ErrInvalidSearchFilter, name, strings.Join(allowed, ", "))
+		}
+	}
+	return values, nil
+}
+
+func isCountryCode(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+	for _, letter := range country {
+		if letter < 'A' || letter > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func setIncludeExpired(filter *repository.SearchFilter, value string) error {
+	includeExpired, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%w: include_expired should be a boolean", ErrInvalidSearchFilter)
+	}
+
+	filter.IncludeExpired = includeExpired
+	if includeExpired {
+		filter.Statuses = []string{model.JobOfferStatusPublished, model.JobOfferStatusExpired}
+	}
+	return nil
+}
+
+// setLocale searches and translates offers in the locale, unless it is the
+// default one.
+func setLocale(filter *repository.SearchFilter, value string) error {
+	locale := utils.NormalizeLocale(value)
+	if !utils.IsSupportedLocale(locale) {
+		return fmt.Errorf("%w: lang should be one of %s", ErrInvalidSearchFilter, strings.Join(utils.SupportedLocales, ", "))
+	}
+
+	if locale != utils.DefaultLocale {
+		filter.Locale = locale
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
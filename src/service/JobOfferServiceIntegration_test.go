@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"jobs-ms/src/auth"
 	"jobs-ms/src/dto"
 	"jobs-ms/src/model"
 	"jobs-ms/src/repository"
@@ -18,6 +20,7 @@ import (
 type JobOfferServiceIntegrationTestSuite struct {
 	suite.Suite
 	service JobOfferService
+	ctx     context.Context
 	db      *gorm.DB
 	offers  []model.JobOffer
 }
@@ -39,8 +42,11 @@ func (suite *JobOfferServiceIntegrationTestSuite) SetupSuite() {
 	)
 	db, _ := gorm.Open("postgres", connectionString)
 
-	db.AutoMigrate(model.JobOffer{})
-	db.Where("1 = 1").Delete(model.JobOffer{})
+	repository.Migrate(db)
+	db.Exec("DELETE FROM job_offer_skills")
+	db.Exec("DELETE FROM job_offer_revisions")
+	db.Exec("DELETE FROM outbox_events")
+	db.Unscoped().Where("1 = 1").Delete(model.JobOffer{})
 
 	jobOfferRepository := repository.JobOfferRepository{Database: db}
 
@@ -48,8 +54,11 @@ func (suite *JobOfferServiceIntegrationTestSuite) SetupSuite() {
 
 	suite.service = JobOfferService{
 		JobOfferRepo: &jobOfferRepository,
+		MemberRepo:   &repository.CompanyMemberRepository{Database: db},
+		AuditRepo:    &repository.AuditRepository{Database: db},
 		Logger:       utils.Logger(),
 	}
+	suite.ctx = auth.NewContext(context.Background(), &auth.Identity{UserID: 1, Roles: []string{auth.RolePlatformAdmin}})
 
 	suite.offers = []model.JobOffer{
 		{
@@ -57,30 +66,23 @@ func (suite *JobOfferServiceIntegrationTestSuite) SetupSuite() {
 			Position:                   "QA",
 			JobDescription:             "test",
 			DailyActivitiesDescription: "test test test",
-			Skills:                     "test",
+			Skills:                     []model.Skill{{Name: "test"}},
 			Link:                       "test link",
+			Status:                     model.JobOfferStatusPublished,
 		},
 		{
 			CompanyID:                  2000,
 			Position:                   "QA",
 			JobDescription:             "test",
 			DailyActivitiesDescription: "test test test",
-			Skills:                     "test",
+			Skills:                     []model.Skill{{Name: "test"}},
 			Link:                       "test link",
+			Status:                     model.JobOfferStatusPublished,
 		},
 	}
 
-	tx := suite.db.Begin()
-
-	tx.Create(&suite.offers[0])
-
-	tx.Commit()
-
-	tx = suite.db.Begin()
-
-	tx.Create(&suite.offers[1])
-
-	tx.Commit()
+	suite.offers[0], _ = jobOfferRepository.Add(suite.offers[0])
+	suite.offers[1], _ = jobOfferRepository.Add(suite.offers[1])
 }
 
 func TestJobOfferServiceIntegrationTestSuite(t *testing.T) {
@@ -90,7 +92,7 @@ func TestJobOfferServiceIntegrationTestSuite(t *testing.T) {
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_GetById_JobOfferDoesNotExist() {
 	id := 2000000
 
-	offer, err := suite.service.GetById(id)
+	offer, err := suite.service.GetById(suite.ctx, id, "en")
 
 	assert.Nil(suite.T(), offer)
 	assert.NotNil(suite.T(), err)
@@ -99,7 +101,7 @@ func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_GetById_JobOfferExists() {
 	id := 1
 
-	offer, err := suite.service.GetById(id)
+	offer, err := suite.service.GetById(suite.ctx, id, "en")
 
 	assert.NotNil(suite.T(), offer)
 	assert.Equal(suite.T(), id, offer.ID)
@@ -107,38 +109,38 @@ func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService
 }
 
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_GetAll_JobOffersExist() {
-	offers, err := suite.service.GetAll()
+	offers, err := suite.service.GetAll(map[string][]string{}, dto.PageRequestDTO{})
 
 	assert.NotNil(suite.T(), offers)
-	assert.Equal(suite.T(), 2, len(offers))
+	assert.Equal(suite.T(), 2, len(offers.Items))
 	assert.Nil(suite.T(), err)
 }
 
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_GetCompanysOffers_OneJobOfferExists() {
 	companyId := 2000
 
-	offers, err := suite.service.GetCompanysOffers(companyId)
+	offers, err := suite.service.GetCompanysOffers(companyId, map[string][]string{}, dto.PageRequestDTO{})
 
 	assert.NotNil(suite.T(), offers)
-	assert.GreaterOrEqual(suite.T(), 1, len(offers))
-	assert.Equal(suite.T(), companyId, offers[0].CompanyID)
+	assert.GreaterOrEqual(suite.T(), 1, len(offers.Items))
+	assert.Equal(suite.T(), companyId, offers.Items[0].CompanyID)
 	assert.Nil(suite.T(), err)
 }
 
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_GetCompanysOffers_NoJobOffers() {
 	companyId := 100000
 
-	offers, err := suite.service.GetCompanysOffers(companyId)
+	offers, err := suite.service.GetCompanysOffers(companyId, map[string][]string{}, dto.PageRequestDTO{})
 
 	assert.NotNil(suite.T(), offers)
-	assert.Equal(suite.T(), 0, len(offers))
+	assert.Equal(suite.T(), 0, len(offers.Items))
 	assert.Nil(suite.T(), err)
 }
 
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Delete_JobOfferDoesNotExist() {
 	id := 2000000
 
-	suite.service.Delete(id)
+	suite.service.Delete(suite.ctx, id)
 
 	assert.True(suite.T(), true)
 }
@@ -146,21 +148,21 @@ func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Search_JobOfferDoesNotExist() {
 	param := "nonexisting param"
 
-	offers, err := suite.service.Search(param)
+	offers, err := suite.service.Search(map[string][]string{"param": {param}}, dto.PageRequestDTO{})
 
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), offers)
-	assert.Equal(suite.T(), 0, len(offers))
+	assert.Equal(suite.T(), 0, len(offers.Items))
 }
 
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Search_JobOffersExist() {
 	param := "test"
 
-	offers, err := suite.service.Search(param)
+	offers, err := suite.service.Search(map[string][]string{"param": {param}}, dto.PageRequestDTO{})
 
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), offers)
-	assert.Equal(suite.T(), 2, len(offers))
+	assert.Equal(suite.T(), 2, len(offers.Items))
 }
 
 func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Add_Pass() {
@@ -169,11 +171,11 @@ func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService
 		JobDescription:             "test",
 		DailyActivitiesDescription: "test",
 		Position:                   "test",
-		Skills:                     "test",
+		Skills:                     []string{"test"},
 		Link:                       "test",
 	}
 
-	responseDto, err := suite.service.Add(&offerDto)
+	responseDto, err := suite.service.Add(suite.ctx, &offerDto)
 
 	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), responseDto)
@@ -183,5 +185,172 @@ func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService
 	assert.Equal(suite.T(), offerDto.CompanyID, responseDto.CompanyID)
 	assert.Equal(suite.T(), offerDto.Position, responseDto.Position)
 
-	suite.service.Delete(responseDto.ID)
+	suite.service.Delete(suite.ctx, responseDto.ID)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Update_JobOfferDoesNotExist() {
+	offerDto := dto.JobOfferRequestDTO{
+		CompanyID:                  1000,
+		JobDescription:             "test",
+		DailyActivitiesDescription: "test",
+		Position:                   "test",
+		Skills:                     []string{"test"},
+		Link:                       "test",
+	}
+
+	responseDto, err := suite.service.Update(suite.ctx, 2000000, &offerDto)
+
+	assert.Nil(suite.T(), responseDto)
+	assert.Equal(suite.T(), repository.ErrJobOfferNotFound, err)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_GetAll_PaginatesWithCursor() {
+	first, err := suite.service.GetAll(map[string][]string{}, dto.PageRequestDTO{Limit: 1, IncludeTotal: true})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(first.Items))
+	assert.True(suite.T(), first.HasMore)
+	assert.Equal(suite.T(), 2, *first.Total)
+
+	second, err := suite.service.GetAll(map[string][]string{}, dto.PageRequestDTO{Limit: 1, Cursor: first.NextCursor})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(second.Items))
+	assert.False(suite.T(), second.HasMore)
+	assert.NotEqual(suite.T(), first.Items[0].ID, second.Items[0].ID)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Search_ByCompanyAndSkills() {
+	filters := map[string][]string{"company_id": {"2000"}, "skills": {"test"}}
+
+	offers, err := suite.service.Search(filters, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(offers.Items))
+	assert.Equal(suite.T(), 2000, offers.Items[0].CompanyID)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Search_RanksByRelevance() {
+	offers, err := suite.service.Search(map[string][]string{"q": {"tests"}}, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(offers.Items))
+	assert.Greater(suite.T(), offers.Items[0].Relevance, 0.0)
+	assert.GreaterOrEqual(suite.T(), offers.Items[0].Relevance, offers.Items[1].Relevance)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_GetAll_FiltersBySkill() {
+	offers, err := suite.service.GetAll(map[string][]string{"skill": {"TEST"}}, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(offers.Items))
+	assert.Equal(suite.T(), []string{"test"}, offers.Items[0].Skills)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Publish_DraftBecomesListed() {
+	offerDto := dto.JobOfferRequestDTO{
+		CompanyID:                  3000,
+		JobDescription:             "draft",
+		DailyActivitiesDescription: "draft",
+		Position:                   "draft",
+		Skills:                     []string{"draft"},
+		Link:                       "draft",
+		Status:                     model.JobOfferStatusDraft,
+	}
+
+	draft, _ := suite.service.Add(suite.ctx, &offerDto)
+	listed, _ := suite.service.Search(map[string][]string{"company_id": {"3000"}}, dto.PageRequestDTO{})
+	assert.Equal(suite.T(), 0, len(listed.Items))
+
+	published, err := suite.service.Publish(suite.ctx, draft.ID)
+	listed, _ = suite.service.Search(map[string][]string{"company_id": {"3000"}}, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), model.JobOfferStatusPublished, published.Status)
+	assert.Equal(suite.T(), 1, len(listed.Items))
+
+	suite.service.Delete(suite.ctx, draft.ID)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Delete_MovesToTrashAndRestores() {
+	offerDto := dto.JobOfferRequestDTO{
+		CompanyID:                  4000,
+		JobDescription:             "trash",
+		DailyActivitiesDescription: "trash",
+		Position:                   "trash",
+		Skills:                     []string{"trash"},
+		Link:                       "trash",
+	}
+	added, _ := suite.service.Add(suite.ctx, &offerDto)
+
+	suite.service.Delete(suite.ctx, added.ID)
+
+	_, err := suite.service.GetById(suite.ctx, added.ID, "en")
+	assert.Equal(suite.T(), repository.ErrJobOfferNotFound, err)
+	trash, _ := suite.service.GetTrash(suite.ctx, 4000)
+	assert.Equal(suite.T(), 1, len(trash))
+	assert.NotNil(suite.T(), trash[0].DeletedAt)
+
+	restored, err := suite.service.Restore(suite.ctx, added.ID)
+
+	assert.Nil(suite.T(), err)
+	assert.Nil(suite.T(), restored.DeletedAt)
+	assert.Equal(suite.T(), []string{"trash"}, restored.Skills)
+
+	suite.service.Delete(suite.ctx, added.ID)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Revisions_RecordsChangesAndReverts() {
+	offerDto := dto.JobOfferRequestDTO{
+		CompanyID:                  5000,
+		JobDescription:             "revision",
+		DailyActivitiesDescription: "revision",
+		Position:                   "Junior",
+		Skills:                     []string{"revision"},
+		Link:                       "revision",
+	}
+	added, _ := suite.service.Add(suite.ctx, &offerDto)
+	offerDto.Position = "Senior"
+	suite.service.Update(suite.ctx, added.ID, &offerDto)
+
+	revisions, err := suite.service.GetRevisions(suite.ctx, added.ID)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(revisions))
+	assert.Equal(suite.T(), model.RevisionActionUpdated, revisions[1].Action)
+	assert.Equal(suite.T(), "user:1", revisions[1].ChangedBy)
+	assert.Equal(suite.T(), []dto.FieldChangeDTO{{Field: "Position", OldValue: "Junior", NewValue: "Senior"}}, revisions[1].Changes)
+
+	reverted, err := suite.service.RevertToRevision(suite.ctx, added.ID, 1)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "Junior", reverted.Position)
+	revisions, _ = suite.service.GetRevisions(suite.ctx, added.ID)
+	assert.Equal(suite.T(), 3, len(revisions))
+	assert.Equal(suite.T(), 1, *revisions[2].SourceRevision)
+
+	suite.service.Delete(suite.ctx, added.ID)
+}
+
+func (suite *JobOfferServiceIntegrationTestSuite) TestIntegrationJobOfferService_Add_SavesOutboxEvent() {
+	offerDto := dto.JobOfferRequestDTO{
+		CompanyID:                  6000,
+		JobDescription:             "outbox",
+		DailyActivitiesDescription: "outbox",
+		Position:                   "outbox",
+		Skills:                     []string{"outbox"},
+		Link:                       "outbox",
+	}
+	added, _ := suite.service.Add(suite.ctx, &offerDto)
+
+	var events []model.OutboxEvent
+	suite.db.Where("aggregate_id = ?", added.ID).Find(&events)
+
+	assert.Equal(suite.T(), 1, len(events))
+	assert.Equal(suite.T(), model.EventJobOfferCreated, events[0].EventType)
+	assert.Equal(suite.T(), 6000, events[0].CompanyID)
+	assert.Equal(suite.T(), 1, events[0].Revision)
+	assert.Nil(suite.T(), events[0].SentAt)
+
+	suite.service.Delete(suite.ctx, added.ID)
 }
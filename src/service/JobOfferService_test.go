@@ -1,11 +1,15 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"jobs-ms/src/auth"
 	"jobs-ms/src/dto"
 	"jobs-ms/src/model"
 	"jobs-ms/src/repository"
 	"jobs-ms/src/utils"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -13,8 +17,11 @@ import (
 
 type JobOfferServiceUnitTestsSuite struct {
 	suite.Suite
-	offerRepositoryMock *repository.JobOfferRepositoryMock
-	service             IJobOfferService
+	offerRepositoryMock  *repository.JobOfferRepositoryMock
+	memberRepositoryMock *repository.CompanyMemberRepositoryMock
+	auditRepositoryMock  *repository.AuditRepositoryMock
+	service              IJobOfferService
+	ctx                  context.Context
 }
 
 func TestJobOfferServiceUnitTestsSuite(t *testing.T) {
@@ -23,7 +30,10 @@ func TestJobOfferServiceUnitTestsSuite(t *testing.T) {
 
 func (suite *JobOfferServiceUnitTestsSuite) SetupSuite() {
 	suite.offerRepositoryMock = new(repository.JobOfferRepositoryMock)
-	suite.service = NewJobOfferService(suite.offerRepositoryMock, utils.Logger())
+	suite.memberRepositoryMock = new(repository.CompanyMemberRepositoryMock)
+	suite.auditRepositoryMock = new(repository.AuditRepositoryMock)
+	suite.service = NewJobOfferService(suite.offerRepositoryMock, suite.memberRepositoryMock, suite.auditRepositoryMock, utils.Logger())
+	suite.ctx = auth.NewContext(context.Background(), &auth.Identity{UserID: 1, Roles: []string{auth.RolePlatformAdmin}})
 }
 
 func (suite *JobOfferServiceUnitTestsSuite) TestNewJobOfferService() {
@@ -33,7 +43,7 @@ func (suite *JobOfferServiceUnitTestsSuite) TestNewJobOfferService() {
 func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_ValidDataProvided() {
 	dto := dto.JobOfferRequestDTO{
 		CompanyID:                  1,
-		Skills:                     "skills",
+		Skills:                     []string{"skills"},
 		JobDescription:             "desc",
 		DailyActivitiesDescription: "desc",
 		Position:                   "pos",
@@ -42,16 +52,18 @@ func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_ValidDataPro
 
 	entity := model.JobOffer{
 		CompanyID:                  1,
-		Skills:                     "skills",
+		Skills:                     []model.Skill{{Name: "skills"}},
 		JobDescription:             "desc",
 		DailyActivitiesDescription: "desc",
 		Position:                   "pos",
 		Link:                       "link",
+		Status:                     "published",
+		UpdatedBy:                  "user:1",
 	}
 
 	savedEntity := model.JobOffer{
 		CompanyID:                  1,
-		Skills:                     "skills",
+		Skills:                     []model.Skill{{Name: "skills"}},
 		JobDescription:             "desc",
 		DailyActivitiesDescription: "desc",
 		Position:                   "pos",
@@ -61,7 +73,7 @@ func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_ValidDataPro
 
 	suite.offerRepositoryMock.On("Add", entity).Return(savedEntity, nil).Once()
 
-	returnedOffer, err := suite.service.Add(&dto)
+	returnedOffer, err := suite.service.Add(suite.ctx, &dto)
 
 	assert.Equal(suite.T(), dto.CompanyID, returnedOffer.CompanyID)
 	assert.Equal(suite.T(), dto.Link, returnedOffer.Link)
@@ -83,21 +95,22 @@ func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetById_GetsJob
 
 	offer := model.JobOffer{
 		ID:                         id,
+		Status:                     model.JobOfferStatusPublished,
 		JobDescription:             description,
 		CompanyID:                  companyId,
 		Position:                   position,
-		Skills:                     skills,
+		Skills:                     []model.Skill{{Name: skills}},
 		Link:                       link,
 		DailyActivitiesDescription: activities,
 	}
 
 	suite.offerRepositoryMock.On("GetById", 1).Return(&offer, nil).Once()
 
-	dto, err := suite.service.GetById(1)
+	dto, err := suite.service.GetById(context.Background(), 1, "en")
 
 	assert.Equal(suite.T(), nil, err)
 	assert.Equal(suite.T(), description, dto.JobDescription)
-	assert.Equal(suite.T(), skills, dto.Skills)
+	assert.Equal(suite.T(), []string{skills}, dto.Skills)
 	assert.Equal(suite.T(), position, dto.Position)
 	assert.Equal(suite.T(), activities, dto.DailyActivitiesDescription)
 	assert.Equal(suite.T(), link, dto.Link)
@@ -106,56 +119,1183 @@ func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetById_GetsJob
 
 func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Delete_Pass() {
 	id := 1
-	suite.offerRepositoryMock.On("Delete", id).Return(nil).Once()
+	suite.offerRepositoryMock.On("GetCompanyID", id).Return(1, nil).Once()
+	suite.offerRepositoryMock.On("Delete", id, "user:1").Return(nil).Once()
 
-	err := suite.service.Delete(id)
+	err := suite.service.Delete(suite.ctx, id)
 
 	assert.Equal(suite.T(), nil, err)
 }
 
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetById_DraftNotFoundForAnonymousCaller() {
+	draft := model.JobOffer{ID: 2, CompanyID: 8, Status: "draft"}
+	offerId := 2
+	entry := model.AuditEntry{CompanyID: 8, JobOfferID: &offerId, Action: model.AuditActionView, Reason: "caller is not authenticated"}
+	suite.offerRepositoryMock.On("GetById", 2).Return(&draft, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	offer, err := suite.service.GetById(context.Background(), 2, "en")
+
+	assert.Nil(suite.T(), offer)
+	assert.ErrorIs(suite.T(), err, repository.ErrJobOfferNotFound)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetById_DraftFoundForMember() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 31, Roles: []string{auth.RoleCompanyAdmin}})
+	draft := model.JobOffer{ID: 3, CompanyID: 8, Status: "draft"}
+	suite.offerRepositoryMock.On("GetById", 3).Return(&draft, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 31, 8).Return(true, nil).Once()
+
+	offer, err := suite.service.GetById(ctx, 3, "en")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "draft", offer.Status)
+}
+
 func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetCompanysOffers_NoOffersReturnsEmpty() {
-	suite.offerRepositoryMock.On("GetByCompany", 1).Return([]*model.JobOffer{}, nil).Once()
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	suite.offerRepositoryMock.On("GetByCompany", 1, repository.SearchFilter{Statuses: []string{"published"}, MatchAllSkills: true}, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
 
-	offers, err := suite.service.GetCompanysOffers(1)
+	offers, err := suite.service.GetCompanysOffers(1, map[string][]string{}, dto.PageRequestDTO{})
 
 	assert.Equal(suite.T(), nil, err)
-	assert.Equal(suite.T(), 0, len(offers))
+	assert.Equal(suite.T(), 0, len(offers.Items))
+	assert.False(suite.T(), offers.HasMore)
 }
 func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetAll_NoOffersReturnsEmpty() {
-	suite.offerRepositoryMock.On("GetAll").Return([]*model.JobOffer{}, nil).Once()
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	suite.offerRepositoryMock.On("GetAll", repository.SearchFilter{Statuses: []string{"published"}, MatchAllSkills: true}, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
 
-	offers, err := suite.service.GetAll()
+	offers, err := suite.service.GetAll(map[string][]string{}, dto.PageRequestDTO{})
 
 	assert.Equal(suite.T(), nil, err)
-	assert.Equal(suite.T(), 0, len(offers))
+	assert.Equal(suite.T(), 0, len(offers.Items))
 }
 
 func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_ReturnedOffer() {
 	offer := model.JobOffer{
 		CompanyID:                  1,
-		Skills:                     "skills",
+		Skills:                     []model.Skill{{Name: "skills"}},
 		JobDescription:             "test",
 		DailyActivitiesDescription: "desc",
 		Position:                   "pos",
 		Link:                       "link",
 		ID:                         1,
+		Relevance:                  0.6,
 	}
 	var list []*model.JobOffer
 	list = append(list, &offer)
 	param := "test"
 
-	suite.offerRepositoryMock.On("Search", param).Return(list, nil).Once()
+	page := repository.PageRequest{Limit: 20, SortField: "relevance", Descending: true}
+	filter := repository.SearchFilter{Statuses: []string{"published"}, Text: param}
+	suite.offerRepositoryMock.On("Search", filter, page).Return(&repository.JobOfferPage{Offers: list}, nil).Once()
+
+	offers, err := suite.service.Search(map[string][]string{"param": {param}}, dto.PageRequestDTO{})
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), len(list), len(offers.Items))
+	for i := 0; i < len(offers.Items); i++ {
+		assert.Equal(suite.T(), list[i].ID, offers.Items[i].ID)
+		assert.Equal(suite.T(), list[i].CompanyID, offers.Items[i].CompanyID)
+		assert.Equal(suite.T(), list[i].Link, offers.Items[i].Link)
+		assert.Equal(suite.T(), list[i].Skills[0].Name, offers.Items[i].Skills[0])
+		assert.Equal(suite.T(), list[i].JobDescription, offers.Items[i].JobDescription)
+		assert.Equal(suite.T(), list[i].DailyActivitiesDescription, offers.Items[i].DailyActivitiesDescription)
+		assert.Equal(suite.T(), list[i].Relevance, offers.Items[i].Relevance)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Update_ValidDataProvided() {
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  1,
+		Skills:                     []string{"new skills"},
+		JobDescription:             "new desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+
+	existing := model.JobOffer{
+		ID:                         2,
+		CompanyID:                  1,
+		Skills:                     []model.Skill{{Name: "skills"}},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+
+	entity := model.JobOffer{
+		ID:                         2,
+		CompanyID:                  1,
+		Skills:                     []model.Skill{{Name: "new skills"}},
+		JobDescription:             "new desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+		UpdatedBy:                  "user:1",
+	}
+
+	suite.offerRepositoryMock.On("GetById", 2).Return(&existing, nil).Once()
+	suite.offerRepositoryMock.On("Update", entity).Return(entity, nil).Once()
+
+	returnedOffer, err := suite.service.Update(suite.ctx, 2, &dto)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), 2, returnedOffer.ID)
+	assert.Equal(suite.T(), dto.Skills, returnedOffer.Skills)
+	assert.Equal(suite.T(), dto.JobDescription, returnedOffer.JobDescription)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Update_JobOfferDoesNotExist() {
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  1,
+		Skills:                     []string{"skills"},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+
+	suite.offerRepositoryMock.On("GetById", 3).Return(nil, repository.ErrJobOfferNotFound).Once()
+
+	returnedOffer, err := suite.service.Update(suite.ctx, 3, &dto)
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.Equal(suite.T(), repository.ErrJobOfferNotFound, err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Update_InvalidDataProvided() {
+	dto := dto.JobOfferRequestDTO{
+		CompanyID: 1,
+		Position:  "pos",
+	}
+
+	returnedOffer, err := suite.service.Update(suite.ctx, 4, &dto)
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Patch_ChangesOnlyPatchedFields() {
+	existing := model.JobOffer{
+		ID:                         5,
+		CompanyID:                  1,
+		Skills:                     []model.Skill{{Name: "skills"}},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "activities",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+
+	entity := existing
+	entity.Position = "new pos"
+	entity.UpdatedBy = "user:1"
+
+	suite.offerRepositoryMock.On("GetById", 5).Return(&existing, nil).Twice()
+	suite.offerRepositoryMock.On("Update", entity).Return(entity, nil).Once()
+
+	returnedOffer, err := suite.service.Patch(suite.ctx, 5, []byte(`{"position": "new pos"}`))
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), "new pos", returnedOffer.Position)
+	assert.Equal(suite.T(), existing.JobDescription, returnedOffer.JobDescription)
+	assert.Equal(suite.T(), existing.DailyActivitiesDescription, returnedOffer.DailyActivitiesDescription)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Patch_RemovingRequiredFieldFails() {
+	existing := model.JobOffer{
+		ID:                         6,
+		CompanyID:                  1,
+		Skills:                     []model.Skill{{Name: "skills"}},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "activities",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+
+	suite.offerRepositoryMock.On("GetById", 6).Return(&existing, nil).Once()
+
+	returnedOffer, err := suite.service.Patch(suite.ctx, 6, []byte(`{"link": null}`))
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Patch_RemovingSkillsFails() {
+	existing := model.JobOffer{
+		ID:                         9,
+		CompanyID:                  1,
+		Skills:                     []model.Skill{{Name: "skills"}},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "activities",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+
+	suite.offerRepositoryMock.On("GetById", 9).Return(&existing, nil).Once()
+
+	returnedOffer, err := suite.service.Patch(suite.ctx, 9, []byte(`{"skills": null}`))
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetAll_ReturnsNextCursor() {
+	offers := []*model.JobOffer{{ID: 7, Position: "pos"}, {ID: 8, Position: "pos"}}
+	next := &repository.Cursor{SortField: "position", Descending: true, Value: "pos", ID: 8}
+	page := repository.PageRequest{Limit: 2, SortField: "position", Descending: true}
+	suite.offerRepositoryMock.On("GetAll", repository.SearchFilter{Statuses: []string{"published"}, MatchAllSkills: true}, page).Return(&repository.JobOfferPage{Offers: offers, HasMore: true, Next: next}, nil).Once()
+
+	result, err := suite.service.GetAll(map[string][]string{}, dto.PageRequestDTO{Limit: 2, Sort: "-position"})
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), 2, len(result.Items))
+	assert.True(suite.T(), result.HasMore)
+	assert.Equal(suite.T(), next.Encode(), result.NextCursor)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetAll_PassesDecodedCursor() {
+	cursor := &repository.Cursor{SortField: "created_at", Value: "2022-06-01T10:00:00Z", ID: 3}
+	page := repository.PageRequest{Limit: 20, SortField: "created_at", After: cursor, WithTotal: true}
+	total := 4
+	suite.offerRepositoryMock.On("GetAll", repository.SearchFilter{Statuses: []string{"published"}, MatchAllSkills: true}, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}, Total: &total}, nil).Once()
+
+	result, err := suite.service.GetAll(map[string][]string{}, dto.PageRequestDTO{Sort: "created_at", Cursor: cursor.Encode(), IncludeTotal: true})
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), &total, result.Total)
+	assert.Equal(suite.T(), "", result.NextCursor)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetAll_InvalidPageRequest() {
+	invalid := []dto.PageRequestDTO{
+		{Limit: 1000},
+		{Limit: -1},
+		{Sort: "link"},
+		{Cursor: "not a cursor"},
+		{Sort: "-id", Cursor: (&repository.Cursor{SortField: "id", ID: 3}).Encode()},
+	}
+
+	for _, pageDTO := range invalid {
+		result, err := suite.service.GetAll(map[string][]string{}, pageDTO)
+
+		assert.Nil(suite.T(), result)
+		assert.ErrorIs(suite.T(), err, ErrInvalidPageRequest)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_BuildsStructuredFilter() {
+	companyID := 3
+	page := repository.PageRequest{Limit: 20, SortField: "relevance", Descending: true}
+	filter := repository.SearchFilter{
+		Statuses:       []string{"published"},
+		CompanyID:      &companyID,
+		Position:       "developer",
+		Skills:         []string{"go", "sql", "docker"},
+		MatchAllSkills: true,
+		Text:           "remote",
+		TextFields:     []string{"job_description", "activities_description"},
+	}
+	suite.offerRepositoryMock.On("Search", filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
+
+	offers, err := suite.service.Search(map[string][]string{
+		"company_id":   {"3"},
+		"position":     {"developer"},
+		"skills":       {"go, sql", "docker"},
+		"skills_match": {"all"},
+		"q":            {"remote"},
+		"fields":       {"job_description,activities_description"},
+	}, dto.PageRequestDTO{})
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), 0, len(offers.Items))
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_InvalidFilter() {
+	invalid := []map[string][]string{
+		{"salary": {"1000"}},
+		{"company_id": {"abc"}},
+		{"skills_match": {"some"}},
+		{"fields": {"position,link"}},
+		{"position": {"qa", "dev"}},
+		{"q": {"go"}, "param": {"java"}},
+	}
+
+	for _, filters := range invalid {
+		offers, err := suite.service.Search(filters, dto.PageRequestDTO{})
+
+		assert.Nil(suite.T(), offers)
+		assert.ErrorIs(suite.T(), err, ErrInvalidSearchFilter)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_SortsByRequestedField() {
+	page := repository.PageRequest{Limit: 20, SortField: "created_at", Descending: true}
+	filter := repository.SearchFilter{Statuses: []string{"published"}, Text: "go"}
+	suite.offerRepositoryMock.On("Search", filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
+
+	offers, err := suite.service.Search(map[string][]string{"q": {"go"}}, dto.PageRequestDTO{Sort: "-created_at"})
+
+	assert.Equal(suite.T(), nil, err)
+	assert.NotNil(suite.T(), offers)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_RelevanceRequiresText() {
+	offers, err := suite.service.Search(map[string][]string{"position": {"qa"}}, dto.PageRequestDTO{Sort: "-relevance"})
+
+	assert.Nil(suite.T(), offers)
+	assert.ErrorIs(suite.T(), err, ErrInvalidPageRequest)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_NormalizesSkills() {
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  1,
+		Skills:                     []string{"Golang", "go", " SQL "},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "normalized",
+		Link:                       "link",
+	}
+
+	entity := model.JobOffer{
+		CompanyID:                  1,
+		Skills:                     []model.Skill{{Name: "go"}, {Name: "sql"}},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "normalized",
+		Link:                       "link",
+		Status:                     "published",
+		UpdatedBy:                  "user:1",
+	}
+
+	suite.offerRepositoryMock.On("Add", entity).Return(entity, nil).Once()
+
+	returnedOffer, err := suite.service.Add(suite.ctx, &dto)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), []string{"go", "sql"}, returnedOffer.Skills)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_EmptySkillsFails() {
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  1,
+		Skills:                     []string{},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+
+	returnedOffer, err := suite.service.Add(suite.ctx, &dto)
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetAll_FiltersBySkill() {
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	filter := repository.SearchFilter{Statuses: []string{"published"}, Skills: []string{"go"}, MatchAllSkills: true}
+	suite.offerRepositoryMock.On("GetAll", filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
+
+	offers, err := suite.service.GetAll(map[string][]string{"skill": {"Golang"}}, dto.PageRequestDTO{})
+
+	assert.Equal(suite.T(), nil, err)
+	assert.NotNil(suite.T(), offers)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_AsDraft() {
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  1,
+		Skills:                     []string{"skills"},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "draft",
+		Link:                       "link",
+		Status:                     "draft",
+	}
+
+	entity := model.JobOffer{
+		CompanyID:                  1,
+		Skills:                     []model.Skill{{Name: "skills"}},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "draft",
+		Link:                       "link",
+		Status:                     "draft",
+		UpdatedBy:                  "user:1",
+	}
+
+	suite.offerRepositoryMock.On("Add", entity).Return(entity, nil).Once()
+
+	returnedOffer, err := suite.service.Add(suite.ctx, &dto)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), "draft", returnedOffer.Status)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_InvalidStatusFails() {
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  1,
+		Skills:                     []string{"skills"},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+		Status:                     "closed",
+	}
+
+	returnedOffer, err := suite.service.Add(suite.ctx, &dto)
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Publish_Draft() {
+	draft := model.JobOffer{ID: 10, Status: "draft"}
+	published := model.JobOffer{ID: 10, Status: "published"}
+	suite.offerRepositoryMock.On("GetById", 10).Return(&draft, nil).Once()
+	suite.offerRepositoryMock.On("UpdateStatus", 10, "draft", "published", "user:1").Return(&published, nil).Once()
+
+	offer, err := suite.service.Publish(suite.ctx, 10)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), "published", offer.Status)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Close_Published() {
+	published := model.JobOffer{ID: 11, Status: "published"}
+	closed := model.JobOffer{ID: 11, Status: "closed"}
+	suite.offerRepositoryMock.On("GetById", 11).Return(&published, nil).Once()
+	suite.offerRepositoryMock.On("UpdateStatus", 11, "published", "closed", "user:1").Return(&closed, nil).Once()
+
+	offer, err := suite.service.Close(suite.ctx, 11)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), "closed", offer.Status)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Close_DraftIsInvalidTransition() {
+	draft := model.JobOffer{ID: 12, Status: "draft"}
+	suite.offerRepositoryMock.On("GetById", 12).Return(&draft, nil).Once()
+
+	offer, err := suite.service.Close(suite.ctx, 12)
+
+	assert.Nil(suite.T(), offer)
+	assert.ErrorIs(suite.T(), err, ErrInvalidStatusTransition)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Publish_AlreadyPublishedIsInvalidTransition() {
+	published := model.JobOffer{ID: 13, Status: "published"}
+	suite.offerRepositoryMock.On("GetById", 13).Return(&published, nil).Once()
+
+	offer, err := suite.service.Publish(suite.ctx, 13)
+
+	assert.Nil(suite.T(), offer)
+	assert.ErrorIs(suite.T(), err, ErrInvalidStatusTransition)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_ValidUntilBeforeValidFromFails() {
+	validFrom := time.Date(2022, 6, 10, 0, 0, 0, 0, time.UTC)
+	validUntil := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  1,
+		Skills:                     []string{"skills"},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+		ValidFrom:                  &validFrom,
+		ValidUntil:                 &validUntil,
+	}
+
+	returnedOffer, err := suite.service.Add(suite.ctx, &dto)
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Publish_PastValidUntilIsInvalidTransition() {
+	validUntil := time.Now().Add(-time.Hour)
+	closed := model.JobOffer{ID: 14, Status: "closed", ValidUntil: &validUntil}
+	suite.offerRepositoryMock.On("GetById", 14).Return(&closed, nil).Once()
+
+	offer, err := suite.service.Publish(suite.ctx, 14)
 
-	offers, err := suite.service.Search(param)
+	assert.Nil(suite.T(), offer)
+	assert.ErrorIs(suite.T(), err, ErrInvalidStatusTransition)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_ExpireOffers_ExpiresPublishedOffers() {
+	now := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+	validUntil := now.Add(-time.Minute)
+	published := model.JobOffer{ID: 15, Status: "published", ValidUntil: &validUntil}
+	expired := model.JobOffer{ID: 15, Status: "expired", ValidUntil: &validUntil}
+	suite.offerRepositoryMock.On("GetExpired", now).Return([]*model.JobOffer{&published}, nil).Once()
+	suite.offerRepositoryMock.On("UpdateStatus", 15, "published", "expired", model.RevisionChangedBySystem).Return(&expired, nil).Once()
+
+	offers, err := suite.service.ExpireOffers(now)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), 1, len(offers))
+	assert.Equal(suite.T(), "expired", offers[0].Status)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_ExpireOffers_SkipsOffersClosedInTheMeantime() {
+	now := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+	validUntil := now.Add(-time.Minute)
+	published := model.JobOffer{ID: 17, Status: "published", ValidUntil: &validUntil}
+	suite.offerRepositoryMock.On("GetExpired", now).Return([]*model.JobOffer{&published}, nil).Once()
+	suite.offerRepositoryMock.On("UpdateStatus", 17, "published", "expired", model.RevisionChangedBySystem).Return(nil, repository.ErrJobOfferStatusConflict).Once()
+
+	offers, err := suite.service.ExpireOffers(now)
 
 	assert.Equal(suite.T(), nil, err)
-	assert.Equal(suite.T(), len(list), len(offers))
-	for i := 0; i < len(offers); i++ {
-		assert.Equal(suite.T(), list[i].ID, offers[i].ID)
-		assert.Equal(suite.T(), list[i].CompanyID, offers[i].CompanyID)
-		assert.Equal(suite.T(), list[i].Link, offers[i].Link)
-		assert.Equal(suite.T(), list[i].Skills, offers[i].Skills)
-		assert.Equal(suite.T(), list[i].JobDescription, offers[i].JobDescription)
-		assert.Equal(suite.T(), list[i].DailyActivitiesDescription, offers[i].DailyActivitiesDescription)
+	assert.Equal(suite.T(), 0, len(offers))
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Close_ClosedInTheMeantimeIsInvalidTransition() {
+	published := model.JobOffer{ID: 18, Status: "published"}
+	suite.offerRepositoryMock.On("GetById", 18).Return(&published, nil).Once()
+	suite.offerRepositoryMock.On("UpdateStatus", 18, "published", "closed", "user:1").Return(nil, repository.ErrJobOfferStatusConflict).Once()
+
+	offer, err := suite.service.Close(suite.ctx, 18)
+
+	assert.Nil(suite.T(), offer)
+	assert.ErrorIs(suite.T(), err, ErrInvalidStatusTransition)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetAll_IncludeExpired() {
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	filter := repository.SearchFilter{Statuses: []string{"published", "expired"}, IncludeExpired: true, MatchAllSkills: true}
+	suite.offerRepositoryMock.On("GetAll", filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
+
+	offers, err := suite.service.GetAll(map[string][]string{"include_expired": {"true"}}, dto.PageRequestDTO{})
+
+	assert.Equal(suite.T(), nil, err)
+	assert.NotNil(suite.T(), offers)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetCompanysOffers_IncludeExpired() {
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	filter := repository.SearchFilter{Statuses: []string{"published", "expired"}, IncludeExpired: true, MatchAllSkills: true}
+	suite.offerRepositoryMock.On("GetByCompany", 1, filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
+
+	offers, err := suite.service.GetCompanysOffers(1, map[string][]string{"include_expired": {"true"}}, dto.PageRequestDTO{})
+
+	assert.Equal(suite.T(), nil, err)
+	assert.NotNil(suite.T(), offers)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_GetTrash_ReturnsDeletedOffers() {
+	deletedAt := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+	deleted := model.JobOffer{ID: 16, CompanyID: 5, DeletedAt: &deletedAt}
+	suite.offerRepositoryMock.On("GetTrash", 5).Return([]*model.JobOffer{&deleted}, nil).Once()
+
+	offers, err := suite.service.GetTrash(suite.ctx, 5)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), 1, len(offers))
+	assert.Equal(suite.T(), &deletedAt, offers[0].DeletedAt)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_GetTrash_NonMemberForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 30, Roles: []string{auth.RoleCompanyAdmin}})
+	userId := 30
+	entry := model.AuditEntry{UserID: &userId, CompanyID: 5, Action: model.AuditActionListTrash, Reason: "user 30 is not a member of company 5"}
+	suite.memberRepositoryMock.On("IsMember", 30, 5).Return(false, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	offers, err := suite.service.GetTrash(ctx, 5)
+
+	assert.Nil(suite.T(), offers)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.offerRepositoryMock.AssertNotCalled(suite.T(), "GetTrash", 5)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Restore_NotInTrash() {
+	suite.offerRepositoryMock.On("GetCompanyID", 17).Return(3, nil).Once()
+	suite.offerRepositoryMock.On("Restore", 17, "user:1").Return(nil, repository.ErrJobOfferNotFound).Once()
+
+	offer, err := suite.service.Restore(suite.ctx, 17)
+
+	assert.Nil(suite.T(), offer)
+	assert.Equal(suite.T(), repository.ErrJobOfferNotFound, err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_PurgeTrash_Pass() {
+	before := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	suite.offerRepositoryMock.On("Purge", before).Return(int64(2), nil).Once()
+
+	purged, err := suite.service.PurgeTrash(before)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), int64(2), purged)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_GetRevisions_ReturnsFieldChanges() {
+	revisions := []*model.JobOfferRevision{
+		{JobOfferID: 20, Revision: 1, Action: model.RevisionActionCreated, Snapshot: snapshot(model.JobOffer{ID: 20, CompanyID: 1, Position: "pos", Skills: []model.Skill{{Name: "go"}}, Status: "published"})},
+		{JobOfferID: 20, Revision: 2, Action: model.RevisionActionUpdated, ChangedBy: "recruiter", Snapshot: snapshot(model.JobOffer{ID: 20, CompanyID: 1, Position: "new pos", Skills: []model.Skill{{Name: "go"}}, Status: "published"})},
 	}
+	suite.offerRepositoryMock.On("GetCompanyID", 20).Return(1, nil).Once()
+	suite.offerRepositoryMock.On("GetRevisions", 20).Return(revisions, nil).Once()
+
+	res, err := suite.service.GetRevisions(suite.ctx, 20)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), 2, len(res))
+	assert.Contains(suite.T(), res[0].Changes, dto.FieldChangeDTO{Field: "Position", OldValue: nil, NewValue: "pos"})
+	assert.Equal(suite.T(), []dto.FieldChangeDTO{{Field: "Position", OldValue: "pos", NewValue: "new pos"}}, res[1].Changes)
+	assert.Equal(suite.T(), "recruiter", res[1].ChangedBy)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_GetRevisions_UnknownOffer() {
+	suite.offerRepositoryMock.On("GetCompanyID", 21).Return(0, repository.ErrJobOfferNotFound).Once()
+
+	res, err := suite.service.GetRevisions(suite.ctx, 21)
+
+	assert.Nil(suite.T(), res)
+	assert.ErrorIs(suite.T(), err, repository.ErrJobOfferNotFound)
+	suite.offerRepositoryMock.AssertNotCalled(suite.T(), "GetRevisions", 21)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_GetRevisions_NonMemberForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 30, Roles: []string{auth.RoleCompanyAdmin}})
+	userId, offerId := 30, 22
+	entry := model.AuditEntry{UserID: &userId, CompanyID: 8, JobOfferID: &offerId, Action: model.AuditActionListRevisions, Reason: "user 30 is not a member of company 8"}
+	suite.offerRepositoryMock.On("GetCompanyID", 22).Return(8, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 30, 8).Return(false, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	res, err := suite.service.GetRevisions(ctx, 22)
+
+	assert.Nil(suite.T(), res)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.offerRepositoryMock.AssertNotCalled(suite.T(), "GetRevisions", 22)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_RevertToRevision_KeepsStatus() {
+	createdAt := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	existing := model.JobOffer{ID: 22, CompanyID: 1, Position: "new pos", JobDescription: "desc", DailyActivitiesDescription: "desc", Skills: []model.Skill{{Name: "go"}}, Status: model.JobOfferStatusClosed, CreatedAt: createdAt}
+	revision := model.JobOfferRevision{JobOfferID: 22, Revision: 1, Snapshot: snapshot(model.JobOffer{ID: 22, CompanyID: 1, Position: "pos", JobDescription: "desc", DailyActivitiesDescription: "desc", Skills: []model.Skill{{Name: "go"}}, Link: "link", Status: "published"})}
+	entity := model.JobOffer{ID: 22, CompanyID: 1, Position: "pos", JobDescription: "desc", DailyActivitiesDescription: "desc", Link: "link", Skills: []model.Skill{{Name: "go"}}, CreatedAt: createdAt, UpdatedBy: "user:1"}
+	reverted := entity
+	reverted.Status = model.JobOfferStatusClosed
+
+	suite.offerRepositoryMock.On("GetById", 22).Return(&existing, nil).Once()
+	suite.offerRepositoryMock.On("GetRevision", 22, 1).Return(&revision, nil).Once()
+	suite.offerRepositoryMock.On("Revert", entity, 1).Return(reverted, nil).Once()
+
+	offer, err := suite.service.RevertToRevision(suite.ctx, 22, 1)
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), "pos", offer.Position)
+	assert.Equal(suite.T(), model.JobOfferStatusClosed, offer.Status)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_RevertToRevision_UnknownRevision() {
+	existing := model.JobOffer{ID: 23, Status: model.JobOfferStatusPublished}
+	suite.offerRepositoryMock.On("GetById", 23).Return(&existing, nil).Once()
+	suite.offerRepositoryMock.On("GetRevision", 23, 5).Return(nil, repository.ErrJobOfferRevisionNotFound).Once()
+
+	offer, err := suite.service.RevertToRevision(suite.ctx, 23, 5)
+
+	assert.Nil(suite.T(), offer)
+	assert.Equal(suite.T(), repository.ErrJobOfferRevisionNotFound, err)
+}
+
+func snapshot(offer model.JobOffer) string {
+	b, _ := json.Marshal(offer)
+	return string(b)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Recommend_RanksByMatchScore() {
+	filter := repository.SearchFilter{
+		Statuses:           []string{model.JobOfferStatusPublished},
+		Skills:             []string{"rust", "kafka"},
+		RankBySkills:       true,
+		ExcludedCompanyIDs: []int{9},
+	}
+	page := repository.PageRequest{Limit: maxRecommendationCandidates, SortField: repository.SortByRelevance, Descending: true}
+	partial := model.JobOffer{ID: 52, Position: "Backend engineer", Skills: []model.Skill{{Name: "kafka"}, {Name: "scala"}}}
+	full := model.JobOffer{ID: 51, Position: "Rust developer", JobDescription: "Streaming with Kafka.", Skills: []model.Skill{{Name: "rust"}}}
+	suite.offerRepositoryMock.On("Search", filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{&partial, &full}}, nil).Once()
+
+	recommendations, err := suite.service.Recommend(&dto.RecommendationRequestDTO{Skills: []string{"Rust", "kafka"}, Position: "rust developer", ExcludedCompanies: []int{9}})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(recommendations))
+	assert.Equal(suite.T(), 51, recommendations[0].Offer.ID)
+	assert.Equal(suite.T(), 0.95, recommendations[0].Score)
+	assert.Equal(suite.T(), []string{"kafka"}, recommendations[0].MentionedSkills)
+	assert.Equal(suite.T(), 52, recommendations[1].Offer.ID)
+	assert.Equal(suite.T(), []string{"kafka"}, recommendations[1].MatchedSkills)
+	assert.Equal(suite.T(), []string{"scala"}, recommendations[1].MissingSkills)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Recommend_NoSkillsFails() {
+	recommendations, err := suite.service.Recommend(&dto.RecommendationRequestDTO{Position: "developer"})
+
+	assert.Nil(suite.T(), recommendations)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestScoreOffer_ExplainsScore() {
+	profile := newCandidateProfile(&dto.RecommendationRequestDTO{Skills: []string{"golang", "Machine Learning", "c++"}, Position: "Senior Go Developer"})
+	offer := model.JobOffer{
+		Position:       "Go developer",
+		JobDescription: "We build machine learning pipelines in C++.",
+		Skills:         []model.Skill{{Name: "go"}, {Name: "docker"}},
+	}
+
+	recommendation := scoreOffer(profile, &offer)
+
+	assert.Equal(suite.T(), dto.ScoreBreakdownDTO{Skills: 0.5, Position: 0.667, Description: 0.667}, recommendation.Breakdown)
+	assert.Equal(suite.T(), 0.55, recommendation.Score)
+	assert.Equal(suite.T(), []string{"go"}, recommendation.MatchedSkills)
+	assert.Equal(suite.T(), []string{"docker"}, recommendation.MissingSkills)
+	assert.Equal(suite.T(), []string{"machine learning", "c++"}, recommendation.MentionedSkills)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_InvalidSalaryFails() {
+	low, high := 1000.0, 2000.0
+	invalid := []dto.JobOfferRequestDTO{
+		{SalaryMin: &high, SalaryMax: &low, Currency: "EUR", Period: "monthly"},
+		{SalaryMin: &low, Currency: "EURO", Period: "monthly"},
+		{SalaryMin: &low, Currency: "EUR"},
+		{SalaryMax: &high, Period: "yearly"},
+		{SalaryMin: &low, Currency: "EUR", Period: "weekly"},
+		{SalaryMin: &low, Currency: "XAU", Period: "monthly"},
+		{SalaryMin: &low, Currency: "XXX", Period: "monthly"},
+	}
+
+	for _, offerDTO := range invalid {
+		offerDTO.CompanyID = 1
+		offerDTO.Skills = []string{"go"}
+		offerDTO.JobDescription = "desc"
+		offerDTO.DailyActivitiesDescription = "desc"
+		offerDTO.Position = "pos"
+		offerDTO.Link = "link"
+
+		offer, err := suite.service.Add(suite.ctx, &offerDTO)
+
+		assert.Nil(suite.T(), offer)
+		assert.NotNil(suite.T(), err)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_UpperCasesCurrency() {
+	low := 1000.0
+	offerDTO := dto.JobOfferRequestDTO{
+		CompanyID:                  1,
+		Skills:                     []string{"skills"},
+		JobDescription:             "paid in usd",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+		SalaryMin:                  &low,
+		Currency:                   " usd",
+		Period:                     "monthly",
+	}
+	entity := model.JobOffer{
+		CompanyID:                  1,
+		Skills:                     []model.Skill{{Name: "skills"}},
+		JobDescription:             "paid in usd",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+		Status:                     "published",
+		SalaryMin:                  &low,
+		Currency:                   "USD",
+		SalaryPeriod:               "monthly",
+		UpdatedBy:                  "user:1",
+	}
+	suite.offerRepositoryMock.On("Add", entity).Return(entity, nil).Once()
+
+	offer, err := suite.service.Add(suite.ctx, &offerDTO)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "USD", offer.Currency)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_ConvertsSalaryToYearlyBase() {
+	salary := 48000.0
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	filter := repository.SearchFilter{Statuses: []string{"published"}, Position: "salaried", SalaryAtLeast: &salary}
+	suite.offerRepositoryMock.On("Search", filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
+
+	offers, err := suite.service.Search(map[string][]string{
+		"position":        {"salaried"},
+		"salary_at_least": {"4000"},
+		"salary_currency": {"eur"},
+		"salary_period":   {"monthly"},
+	}, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), offers)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_InvalidSalaryFilter() {
+	invalid := []map[string][]string{
+		{"salary_at_least": {"lots"}},
+		{"salary_at_least": {"1000"}, "salary_currency": {"XYZ"}},
+		{"salary_at_least": {"1000"}, "salary_currency": {"xts"}},
+		{"salary_at_least": {"1000"}, "salary_period": {"weekly"}},
+		{"salary_currency": {"EUR"}},
+	}
+
+	for _, filters := range invalid {
+		offers, err := suite.service.Search(filters, dto.PageRequestDTO{})
+
+		assert.Nil(suite.T(), offers)
+		assert.ErrorIs(suite.T(), err, ErrInvalidSearchFilter)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_FiltersByLocation() {
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	filter := repository.SearchFilter{
+		Statuses:       []string{"published"},
+		Position:       "located",
+		Near:           &repository.NearFilter{Point: repository.GeoPoint{Latitude: 45.2671, Longitude: 19.8335}, RadiusKm: 50},
+		Country:        "RS",
+		RemotePolicies: []string{"onsite", "hybrid"},
+	}
+	suite.offerRepositoryMock.On("Search", filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
+
+	offers, err := suite.service.Search(map[string][]string{
+		"position":      {"located"},
+		"near":          {"45.2671, 19.8335"},
+		"radius_km":     {"50"},
+		"country":       {"rs"},
+		"remote_policy": {"onsite,hybrid"},
+	}, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), offers)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_InvalidLocationFilter() {
+	invalid := []map[string][]string{
+		{"near": {"45.2671,19.8335"}},
+		{"radius_km": {"50"}},
+		{"near": {"45.2671"}, "radius_km": {"50"}},
+		{"near": {"91,19.8335"}, "radius_km": {"50"}},
+		{"near": {"45.2671,181"}, "radius_km": {"50"}},
+		{"near": {"45.2671,19.8335"}, "radius_km": {"0"}},
+		{"near": {"45.2671,19.8335"}, "radius_km": {"30000"}},
+		{"country": {"SRB"}},
+		{"remote_policy": {"onsite,anywhere"}},
+	}
+
+	for _, filters := range invalid {
+		offers, err := suite.service.Search(filters, dto.PageRequestDTO{})
+
+		assert.Nil(suite.T(), offers)
+		assert.ErrorIs(suite.T(), err, ErrInvalidSearchFilter)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Add_InvalidLocation() {
+	latitude, longitude := 45.2671, 19.8335
+	outOfRange := 95.0
+	invalid := []dto.JobOfferRequestDTO{
+		{Latitude: &latitude},
+		{Longitude: &longitude},
+		{Latitude: &outOfRange, Longitude: &longitude},
+		{Country: "Serbia"},
+		{RemotePolicy: "anywhere"},
+	}
+
+	for _, offerDTO := range invalid {
+		offerDTO.CompanyID = 1
+		offerDTO.Skills = []string{"go"}
+		offerDTO.JobDescription = "desc"
+		offerDTO.DailyActivitiesDescription = "desc"
+		offerDTO.Position = "pos"
+		offerDTO.Link = "link"
+
+		offer, err := suite.service.Add(suite.ctx, &offerDTO)
+
+		assert.Nil(suite.T(), offer)
+		assert.NotNil(suite.T(), err)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_FiltersByEmployment() {
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	filter := repository.SearchFilter{
+		Statuses:        []string{"published"},
+		Position:        "employed",
+		EmploymentTypes: []string{"full-time", "contract"},
+		SeniorityLevels: []string{"senior"},
+		WorkSchedules:   []string{"flexible"},
+	}
+	suite.offerRepositoryMock.On("Search", filter, page).Return(&repository.JobOfferPage{Offers: []*model.JobOffer{}}, nil).Once()
+
+	offers, err := suite.service.Search(map[string][]string{
+		"position":        {"employed"},
+		"employment_type": {"full-time, contract"},
+		"seniority":       {"senior"},
+		"work_schedule":   {"flexible"},
+	}, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.NotNil(suite.T(), offers)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_InvalidEmploymentFilter() {
+	invalid := []map[string][]string{
+		{"employment_type": {"full-time,freelance"}},
+		{"seniority": {"rockstar"}},
+		{"work_schedule": {"weekends"}},
+	}
+
+	for _, filters := range invalid {
+		offers, err := suite.service.Search(filters, dto.PageRequestDTO{})
+
+		assert.Nil(suite.T(), offers)
+		assert.ErrorIs(suite.T(), err, ErrInvalidSearchFilter)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Add_InvalidEmployment() {
+	invalid := []dto.JobOfferRequestDTO{
+		{EmploymentType: "freelance"},
+		{Seniority: "rockstar"},
+		{WorkSchedule: "weekends"},
+	}
+
+	for _, offerDTO := range invalid {
+		offerDTO.CompanyID = 1
+		offerDTO.Skills = []string{"go"}
+		offerDTO.JobDescription = "desc"
+		offerDTO.DailyActivitiesDescription = "desc"
+		offerDTO.Position = "pos"
+		offerDTO.Link = "link"
+
+		offer, err := suite.service.Add(suite.ctx, &offerDTO)
+
+		assert.Nil(suite.T(), offer)
+		assert.NotNil(suite.T(), err)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_SaveTranslation_Pass() {
+	defer func(locales []string) { utils.SupportedLocales = locales }(utils.SupportedLocales)
+	utils.SupportedLocales = []string{"en", "de"}
+
+	translation := model.JobOfferTranslation{
+		JobOfferID:                 41,
+		Locale:                     "de",
+		Position:                   "Entwickler",
+		JobDescription:             "Beschreibung",
+		DailyActivitiesDescription: "Aufgaben",
+		Skills:                     []string{"Programmierung"},
+	}
+	suite.offerRepositoryMock.On("GetCompanyID", 41).Return(1, nil).Once()
+	suite.offerRepositoryMock.On("SaveTranslation", translation).Return(translation, nil).Once()
+
+	result, err := suite.service.SaveTranslation(suite.ctx, 41, "DE", &dto.JobOfferTranslationRequestDTO{
+		Position:                   "Entwickler",
+		JobDescription:             "Beschreibung",
+		DailyActivitiesDescription: "Aufgaben",
+		Skills:                     []string{" Programmierung "},
+	})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "de", result.Locale)
+	assert.Equal(suite.T(), []string{"Programmierung"}, result.Skills)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_SaveTranslation_InvalidLocale() {
+	defer func(locales []string) { utils.SupportedLocales = locales }(utils.SupportedLocales)
+	utils.SupportedLocales = []string{"en", "de"}
+	translationDTO := dto.JobOfferTranslationRequestDTO{Position: "pos", JobDescription: "desc", DailyActivitiesDescription: "desc"}
+
+	for _, locale := range []string{"fr", "en"} {
+		result, err := suite.service.SaveTranslation(suite.ctx, 42, locale, &translationDTO)
+
+		assert.Nil(suite.T(), result)
+		assert.ErrorIs(suite.T(), err, ErrInvalidLocale)
+	}
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetById_Translated() {
+	offer := model.JobOffer{ID: 43, Status: "published", Position: "Developer", JobDescription: "Description", Skills: []model.Skill{{Name: "programming"}}}
+	translation := model.JobOfferTranslation{JobOfferID: 43, Locale: "de", Position: "Entwickler", JobDescription: "Beschreibung"}
+	suite.offerRepositoryMock.On("GetById", 43).Return(&offer, nil).Once()
+	suite.offerRepositoryMock.On("GetTranslations", []int{43}, "de").Return([]*model.JobOfferTranslation{&translation}, nil).Once()
+
+	result, err := suite.service.GetById(context.Background(), 43, "de")
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "de", result.Locale)
+	assert.Equal(suite.T(), "Entwickler", result.Position)
+	assert.Equal(suite.T(), "Beschreibung", result.JobDescription)
+	assert.Equal(suite.T(), []string{"programming"}, result.Skills)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_Search_InLocale() {
+	defer func(locales []string) { utils.SupportedLocales = locales }(utils.SupportedLocales)
+	utils.SupportedLocales = []string{"en", "de"}
+
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	filter := repository.SearchFilter{Statuses: []string{"published"}, Position: "entwickler", Locale: "de"}
+	offers := []*model.JobOffer{{ID: 44, Position: "Developer"}, {ID: 45, Position: "Developer"}}
+	translation := model.JobOfferTranslation{JobOfferID: 45, Locale: "de", Position: "Entwickler"}
+	suite.offerRepositoryMock.On("Search", filter, page).Return(&repository.JobOfferPage{Offers: offers}, nil).Once()
+	suite.offerRepositoryMock.On("GetTranslations", []int{44, 45}, "de").Return([]*model.JobOfferTranslation{&translation}, nil).Once()
+
+	result, err := suite.service.Search(map[string][]string{"position": {"entwickler"}, "lang": {"de"}}, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "en", result.Items[0].Locale)
+	assert.Equal(suite.T(), "Developer", result.Items[0].Position)
+	assert.Equal(suite.T(), "de", result.Items[1].Locale)
+	assert.Equal(suite.T(), "Entwickler", result.Items[1].Position)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfficeService_GetCompanysOffers_InLocale() {
+	defer func(locales []string) { utils.SupportedLocales = locales }(utils.SupportedLocales)
+	utils.SupportedLocales = []string{"en", "de"}
+
+	page := repository.PageRequest{Limit: 20, SortField: "id"}
+	filter := repository.SearchFilter{Statuses: []string{"published"}, MatchAllSkills: true, Locale: "de"}
+	offers := []*model.JobOffer{{ID: 46, CompanyID: 1, Position: "Developer"}}
+	translation := model.JobOfferTranslation{JobOfferID: 46, Locale: "de", Position: "Entwickler"}
+	suite.offerRepositoryMock.On("GetByCompany", 1, filter, page).Return(&repository.JobOfferPage{Offers: offers}, nil).Once()
+	suite.offerRepositoryMock.On("GetTranslations", []int{46}, "de").Return([]*model.JobOfferTranslation{&translation}, nil).Once()
+
+	result, err := suite.service.GetCompanysOffers(1, map[string][]string{"lang": {"de"}}, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "de", result.Items[0].Locale)
+	assert.Equal(suite.T(), "Entwickler", result.Items[0].Position)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Publish_NonMemberForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 30, Roles: []string{auth.RoleCompanyAdmin}})
+	draft := model.JobOffer{ID: 50, CompanyID: 8, Status: "draft"}
+	userId, offerId := 30, 50
+	entry := model.AuditEntry{UserID: &userId, CompanyID: 8, JobOfferID: &offerId, Action: model.AuditActionPublish, Reason: "user 30 is not a member of company 8"}
+	suite.offerRepositoryMock.On("GetById", 50).Return(&draft, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 30, 8).Return(false, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	offer, err := suite.service.Publish(ctx, 50)
+
+	assert.Nil(suite.T(), offer)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.auditRepositoryMock.AssertCalled(suite.T(), "Add", entry)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Close_MemberAllowed() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 31, Roles: []string{auth.RoleCompanyAdmin}})
+	published := model.JobOffer{ID: 51, CompanyID: 8, Status: "published"}
+	closed := model.JobOffer{ID: 51, CompanyID: 8, Status: "closed"}
+	suite.offerRepositoryMock.On("GetById", 51).Return(&published, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 31, 8).Return(true, nil).Once()
+	suite.offerRepositoryMock.On("UpdateStatus", 51, "published", "closed", "user:31").Return(&closed, nil).Once()
+
+	offer, err := suite.service.Close(ctx, 51)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "closed", offer.Status)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_AnonymousForbidden() {
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  9,
+		Skills:                     []string{"skills"},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+	entry := model.AuditEntry{CompanyID: 9, Action: model.AuditActionCreate, Reason: "caller is not authenticated"}
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	returnedOffer, err := suite.service.Add(context.Background(), &dto)
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.auditRepositoryMock.AssertCalled(suite.T(), "Add", entry)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Update_MovingToOtherCompanyForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 32, Roles: []string{auth.RoleCompanyAdmin}})
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  11,
+		Skills:                     []string{"skills"},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+	existing := model.JobOffer{ID: 52, CompanyID: 10, Status: "published"}
+	userId, offerId := 32, 52
+	entry := model.AuditEntry{UserID: &userId, CompanyID: 11, JobOfferID: &offerId, Action: model.AuditActionUpdate, Reason: "user 32 is not a member of company 11"}
+	suite.offerRepositoryMock.On("GetById", 52).Return(&existing, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 32, 10).Return(true, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 32, 11).Return(false, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	returnedOffer, err := suite.service.Update(ctx, 52, &dto)
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_ApiTokenOfOtherCompanyForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{TokenID: 6, Companies: []int{12}, Scopes: []string{auth.ScopeCreateJobOffers}})
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  13,
+		Skills:                     []string{"skills"},
+		JobDescription:             "desc",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+	tokenId := 6
+	entry := model.AuditEntry{ApiTokenID: &tokenId, CompanyID: 13, Action: model.AuditActionCreate, Reason: "API token 6 belongs to another company"}
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	returnedOffer, err := suite.service.Add(ctx, &dto)
+
+	assert.Nil(suite.T(), returnedOffer)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.auditRepositoryMock.AssertCalled(suite.T(), "Add", entry)
+}
+
+func (suite *JobOfferServiceUnitTestsSuite) TestJobOfferService_Add_ApiTokenOfCompanyAllowed() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{TokenID: 6, Companies: []int{12}, Scopes: []string{auth.ScopeCreateJobOffers}})
+	dto := dto.JobOfferRequestDTO{
+		CompanyID:                  12,
+		Skills:                     []string{"skills"},
+		JobDescription:             "from ats",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+	}
+	entity := model.JobOffer{
+		CompanyID:                  12,
+		Skills:                     []model.Skill{{Name: "skills"}},
+		JobDescription:             "from ats",
+		DailyActivitiesDescription: "desc",
+		Position:                   "pos",
+		Link:                       "link",
+		Status:                     "published",
+		UpdatedBy:                  "api-token:6",
+	}
+	saved := entity
+	saved.ID = 53
+	suite.offerRepositoryMock.On("Add", entity).Return(saved, nil).Once()
+
+	returnedOffer, err := suite.service.Add(ctx, &dto)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 53, returnedOffer.ID)
 }
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"jobs-ms/src/dto"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
+	"jobs-ms/src/utils"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// The weights of the parts of the match score. They add up to 1, so the score
+// is between 0 and 1 as well.
+const (
+	skillsWeight      = 0.7
+	positionWeight    = 0.2
+	descriptionWeight = 0.1
+)
+
+// candidateProfile is the normalized profile of a candidate offers are
+// scored against.
+type candidateProfile struct {
+	Skills         []string
+	PositionTokens []string
+}
+
+func newCandidateProfile(recommendationDTO *dto.RecommendationRequestDTO) candidateProfile {
+	return candidateProfile{
+		Skills:         utils.NormalizeSkills(recommendationDTO.Skills),
+		PositionTokens: uniqueTokens(recommendationDTO.Position),
+	}
+}
+
+// scoreOffer scores how well the offer matches the profile:
+//   - skills is the share of the skills the offer requires that the candidate
+//     has,
+//   - position is the share of the words of the desired position found in the
+//     position of the offer, or 0 when no position is desired,
+//   - description is the share of the skills of the candidate that the job
+//     description mentions.
+func scoreOffer(profile candidateProfile, offer *model.JobOffer) *dto.RecommendationResponseDTO {
+	result := dto.RecommendationResponseDTO{
+		Offer:           mapper.JobOfferToJobOfferResponseDTO(offer),
+		MatchedSkills:   []string{},
+		MissingSkills:   []string{},
+		MentionedSkills: []string{},
+	}
+
+	hasSkill := map[string]bool{}
+	for _, skill := range profile.Skills {
+		hasSkill[skill] = true
+	}
+	for _, skill := range offer.Skills {
+		if hasSkill[skill.Name] {
+			result.MatchedSkills = append(result.MatchedSkills, skill.Name)
+		} else {
+			result.MissingSkills = append(result.MissingSkills, skill.Name)
+		}
+	}
+	if len(offer.Skills) > 0 {
+		result.Breakdown.Skills = ratio(len(result.MatchedSkills), len(offer.Skills))
+	}
+
+	if len(profile.PositionTokens) > 0 {
+		positionTokens := map[string]bool{}
+		for _, token := range uniqueTokens(offer.Position) {
+			positionTokens[token] = true
+		}
+		found := 0
+		for _, token := range profile.PositionTokens {
+			if positionTokens[token] {
+				found++
+			}
+		}
+		result.Breakdown.Position = ratio(found, len(profile.PositionTokens))
+	}
+
+	mentioned := mentionedSkills(offer.JobDescription)
+	for _, skill := range profile.Skills {
+		if mentioned[skill] {
+			result.MentionedSkills = append(result.MentionedSkills, skill)
+		}
+	}
+	if len(profile.Skills) > 0 {
+		result.Breakdown.Description = ratio(len(result.MentionedSkills), len(profile.Skills))
+	}
+
+	result.Score = round(skillsWeight*result.Breakdown.Skills +
+		positionWeight*result.Breakdown.Position +
+		descriptionWeight*result.Breakdown.Description)
+
+	return &result
+}
+
+// mentionedSkills returns the normalized single and two word phrases of the
+// text, so skills such as "golang" and "machine learning" are recognized.
+func mentionedSkills(text string) map[string]bool {
+	tokens := tokenize(text)
+	phrases := map[string]bool{}
+	for i, token := range tokens {
+		phrases[utils.NormalizeSkill(token)] = true
+		if i > 0 {
+			phrases[utils.NormalizeSkill(tokens[i-1]+" "+token)] = true
+		}
+	}
+	return phrases
+}
+
+// tokenize splits the lower case text into words. Characters used in skill
+// names such as c++, c# and node.js are kept, apart from the dots ending a
+// sentence.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#.", r)
+	})
+
+	tokens := []string{}
+	for _, field := range fields {
+		if field = strings.TrimRight(field, "."); field != "" {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+func uniqueTokens(text string) []string {
+	seen := map[string]bool{}
+	tokens := []string{}
+	for _, token := range tokenize(text) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func ratio(part int, total int) float64 {
+	return round(float64(part) / float64(total))
+}
+
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
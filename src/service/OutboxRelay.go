@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"jobs-ms/src/events"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// OutboxRelay periodically publishes the system events saved in the outbox.
+// Failed deliveries are retried with an exponentially growing delay until
+// MaxAttempts is reached.
+type OutboxRelay struct {
+	OutboxRepo    repository.IOutboxRepository
+	Publisher     events.Publisher
+	Interval      time.Duration
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	MaxAttempts   int
+	BatchSize     int
+	Logger        *logrus.Entry
+}
+
+func (relay *OutboxRelay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(relay.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				relay.Relay(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// Relay delivers the events that are due at the given time and returns how
+// many were delivered. It stops at the first failed delivery, so events are
+// delivered in the order they were saved.
+func (relay *OutboxRelay) Relay(ctx context.Context, now time.Time) int {
+	pending, err := relay.OutboxRepo.GetPending(now, relay.MaxAttempts, relay.BatchSize)
+	if err != nil {
+		relay.Logger.Debug(err.Error())
+		return 0
+	}
+
+	delivered := 0
+	for _, event := range pending {
+		if err := relay.publish(ctx, event); err != nil {
+			relay.Logger.Debug(fmt.Sprintf("Error happened during sending system event with id %d: %s", event.ID, err.Error()))
+			if err := relay.OutboxRepo.MarkFailed(event.ID, now.Add(relay.retryDelay(event.Attempts)), err.Error()); err != nil {
+				relay.Logger.Debug(err.Error())
+			}
+			break
+		}
+
+		if err := relay.OutboxRepo.MarkSent(event.ID, now); err != nil {
+			relay.Logger.Debug(err.Error())
+			break
+		}
+		delivered++
+	}
+
+	if delivered > 0 {
+		relay.Logger.Info(fmt.Sprintf("Successfully sent %d system events", delivered))
+	}
+	return delivered
+}
+
+func (relay *OutboxRelay) publish(ctx context.Context, event *model.OutboxEvent) error {
+	domainEvent, err := toDomainEvent(event)
+	if err != nil {
+		return err
+	}
+
+	return relay.Publisher.Publish(ctx, domainEvent)
+}
+
+// toDomainEvent creates the typed event of the outbox event, carrying the
+// offer as it was saved by the change.
+func toDomainEvent(event *model.OutboxEvent) (events.DomainEvent, error) {
+	switch event.EventType {
+	case model.EventApplicationStageChanged:
+		return toApplicationEvent(event)
+	case model.EventJobAlertCreated:
+		return toJobAlertEvent(event)
+	}
+
+	var offer model.JobOffer
+	if err := json.Unmarshal([]byte(event.Payload), &offer); err != nil {
+		return nil, err
+	}
+
+	return events.NewJobOfferEvent(event.EventType, events.JobOfferEvent{
+		ID:        strconv.Itoa(event.ID),
+		OfferID:   event.AggregateID,
+		CompanyID: event.CompanyID,
+		Revision:  event.Revision,
+		Time:      event.OccurredAt,
+		Offer:     mapper.JobOfferToJobOfferResponseDTO(&offer),
+	})
+}
+
+// toApplicationEvent creates the event of a stage change of an application
+// from the change saved in the outbox event.
+func toApplicationEvent(event *model.OutboxEvent) (events.DomainEvent, error) {
+	var change model.ApplicationStageChange
+	if err := json.Unmarshal([]byte(event.Payload), &change); err != nil {
+		return nil, err
+	}
+
+	return events.NewApplicationStageChanged(events.ApplicationStageChanged{
+		ID:            strconv.Itoa(event.ID),
+		ApplicationID: event.AggregateID,
+		OfferID:       change.Application.JobOfferID,
+		CompanyID:     event.CompanyID,
+		CandidateID:   change.Application.CandidateID,
+		FromStage:     change.FromStage,
+		ToStage:       change.ToStage,
+		Note:          change.Note,
+		Time:          event.OccurredAt,
+	}), nil
+}
+
+// toJobAlertEvent creates the event of an alert from the alert saved in the
+// outbox event.
+func toJobAlertEvent(event *model.OutboxEvent) (events.DomainEvent, error) {
+	var alert model.JobAlert
+	if err := json.Unmarshal([]byte(event.Payload), &alert); err != nil {
+		return nil, err
+	}
+
+	return events.NewJobAlertCreated(events.JobAlertCreated{
+		ID:            strconv.Itoa(event.ID),
+		AlertID:       event.AggregateID,
+		UserID:        alert.UserID,
+		SavedSearchID: alert.SavedSearchID,
+		OfferID:       alert.JobOfferID,
+		CompanyID:     event.CompanyID,
+		Time:          event.OccurredAt,
+	}), nil
+}
+
+// retryDelay doubles the delay with every failed attempt, up to MaxRetryDelay.
+func (relay *OutboxRelay) retryDelay(attempts int) time.Duration {
+	return backoff(relay.RetryDelay, relay.MaxRetryDelay, attempts)
+}
+
+// backoff returns the delay before the next attempt after the given number
+// of failed attempts, doubling the delay with every attempt up to max.
+func backoff(delay time.Duration, max time.Duration, attempts int) time.Duration {
+	for i := 0; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/events"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxRelayUnitTestsSuite struct {
+	suite.Suite
+	outboxRepositoryMock *repository.OutboxRepositoryMock
+	eventsServer         *httptest.Server
+	received             []events.CloudEvent
+	status               int
+	relay                *OutboxRelay
+}
+
+func TestOutboxRelayUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(OutboxRelayUnitTestsSuite))
+}
+
+func (suite *OutboxRelayUnitTestsSuite) SetupTest() {
+	suite.received = nil
+	suite.status = http.StatusOK
+	suite.eventsServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event events.CloudEvent
+		json.NewDecoder(r.Body).Decode(&event)
+		suite.received = append(suite.received, event)
+		w.WriteHeader(suite.status)
+	}))
+
+	publisher, _ := events.NewHTTPPublisher(suite.eventsServer.URL, suite.eventsServer.Client(), events.ModeStructured)
+	suite.outboxRepositoryMock = new(repository.OutboxRepositoryMock)
+	suite.relay = &OutboxRelay{
+		OutboxRepo:    suite.outboxRepositoryMock,
+		Publisher:     publisher,
+		Interval:      time.Second,
+		RetryDelay:    time.Second,
+		MaxRetryDelay: time.Minute,
+		MaxAttempts:   5,
+		BatchSize:     10,
+		Logger:        utils.Logger(),
+	}
+}
+
+func (suite *OutboxRelayUnitTestsSuite) TearDownTest() {
+	suite.eventsServer.Close()
+}
+
+func (suite *OutboxRelayUnitTestsSuite) TestOutboxRelay_Relay_DeliversAndMarksSent() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	offer := model.JobOffer{ID: 3, CompanyID: 7, Position: "pos", Status: model.JobOfferStatusPublished}
+	pending := []*model.OutboxEvent{
+		{ID: 1, EventType: model.EventJobOfferCreated, AggregateID: 3, CompanyID: 7, Revision: 1, Payload: snapshot(offer), OccurredAt: now},
+		{ID: 2, EventType: model.EventJobOfferUpdated, AggregateID: 3, CompanyID: 7, Revision: 2, Payload: snapshot(offer), OccurredAt: now},
+	}
+	suite.outboxRepositoryMock.On("GetPending", now, 5, 10).Return(pending, nil).Once()
+	suite.outboxRepositoryMock.On("MarkSent", 1, now).Return(nil).Once()
+	suite.outboxRepositoryMock.On("MarkSent", 2, now).Return(nil).Once()
+
+	delivered := suite.relay.Relay(context.Background(), now)
+
+	assert.Equal(suite.T(), 2, delivered)
+	assert.Equal(suite.T(), 2, len(suite.received))
+	assert.Equal(suite.T(), "1", suite.received[0].ID)
+	assert.Equal(suite.T(), "jobs.joboffer.JobOfferCreated", suite.received[0].Type)
+	assert.Equal(suite.T(), "3", suite.received[0].Subject)
+	assert.Equal(suite.T(), "2022-06-01T12:00:00Z", suite.received[0].Time)
+	assert.Equal(suite.T(), "jobs.joboffer.JobOfferUpdated", suite.received[1].Type)
+
+	var data events.JobOfferEvent
+	json.Unmarshal(suite.received[0].Data, &data)
+	assert.Equal(suite.T(), 7, data.CompanyID)
+	assert.Equal(suite.T(), events.SchemaVersion, data.SchemaVersion)
+	assert.Equal(suite.T(), "pos", data.Offer.Position)
+	suite.outboxRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *OutboxRelayUnitTestsSuite) TestOutboxRelay_Relay_RetriesFailedDeliveryWithBackoff() {
+	suite.status = http.StatusServiceUnavailable
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	offer := model.JobOffer{ID: 3, CompanyID: 7}
+	pending := []*model.OutboxEvent{
+		{ID: 1, EventType: model.EventJobOfferDeleted, AggregateID: 3, Payload: snapshot(offer), OccurredAt: now, Attempts: 2},
+		{ID: 2, EventType: model.EventJobOfferRestored, AggregateID: 3, Payload: snapshot(offer), OccurredAt: now},
+	}
+	suite.outboxRepositoryMock.On("GetPending", now, 5, 10).Return(pending, nil).Once()
+	suite.outboxRepositoryMock.On("MarkFailed", 1, now.Add(4*time.Second), "events-ms responded with status 503").Return(nil).Once()
+
+	delivered := suite.relay.Relay(context.Background(), now)
+
+	assert.Equal(suite.T(), 0, delivered)
+	assert.Equal(suite.T(), 1, len(suite.received))
+	suite.outboxRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *OutboxRelayUnitTestsSuite) TestOutboxRelay_Relay_EventsServiceDown() {
+	suite.eventsServer.Close()
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	pending := []*model.OutboxEvent{{ID: 1, EventType: model.EventJobOfferClosed, AggregateID: 3, Payload: snapshot(model.JobOffer{ID: 3}), OccurredAt: now}}
+	suite.outboxRepositoryMock.On("GetPending", now, 5, 10).Return(pending, nil).Once()
+	suite.outboxRepositoryMock.On("MarkFailed", 1, now.Add(time.Second), mock.AnythingOfType("string")).Return(nil).Once()
+
+	delivered := suite.relay.Relay(context.Background(), now)
+
+	assert.Equal(suite.T(), 0, delivered)
+	suite.outboxRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *OutboxRelayUnitTestsSuite) TestOutboxRelay_Relay_UnknownEventType() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	pending := []*model.OutboxEvent{{ID: 1, EventType: "JobOfferArchived", AggregateID: 3, Payload: snapshot(model.JobOffer{ID: 3}), OccurredAt: now}}
+	suite.outboxRepositoryMock.On("GetPending", now, 5, 10).Return(pending, nil).Once()
+	suite.outboxRepositoryMock.On("MarkFailed", 1, now.Add(time.Second), "Unknown event type JobOfferArchived").Return(nil).Once()
+
+	delivered := suite.relay.Relay(context.Background(), now)
+
+	assert.Equal(suite.T(), 0, delivered)
+	assert.Equal(suite.T(), 0, len(suite.received))
+}
+
+func (suite *OutboxRelayUnitTestsSuite) TestOutboxRelay_Relay_PublishesTypedEvents() {
+	publisher := events.NewMemoryPublisher()
+	suite.relay.Publisher = publisher
+	now := time.Date(2022, 6, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	offer := model.JobOffer{ID: 3, CompanyID: 7, Position: "pos", Status: model.JobOfferStatusPublished}
+	pending := []*model.OutboxEvent{{ID: 4, EventType: model.EventJobOfferPublished, AggregateID: 3, CompanyID: 7, Revision: 2, Payload: snapshot(offer), OccurredAt: now}}
+	suite.outboxRepositoryMock.On("GetPending", now, 5, 10).Return(pending, nil).Once()
+	suite.outboxRepositoryMock.On("MarkSent", 4, now).Return(nil).Once()
+
+	delivered := suite.relay.Relay(context.Background(), now)
+
+	assert.Equal(suite.T(), 1, delivered)
+	assert.Equal(suite.T(), []events.DomainEvent{events.JobOfferPublished{JobOfferEvent: events.JobOfferEvent{
+		ID:            "4",
+		SchemaVersion: events.SchemaVersion,
+		OfferID:       3,
+		CompanyID:     7,
+		Revision:      2,
+		Time:          time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC),
+		Offer:         &dto.JobOfferResponseDTO{ID: 3, CompanyID: 7, Position: "pos", Skills: []string{}, Status: model.JobOfferStatusPublished, Stages: model.DefaultApplicationStages, Locale: utils.DefaultLocale},
+	}}}, publisher.Events())
+}
+
+func (suite *OutboxRelayUnitTestsSuite) TestOutboxRelay_Relay_PublishesApplicationEvents() {
+	publisher := events.NewMemoryPublisher()
+	suite.relay.Publisher = publisher
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	change := model.ApplicationStageChange{
+		ApplicationID: 5,
+		Application:   model.Application{ID: 5, JobOfferID: 3, CandidateID: 9, Stage: model.ApplicationStageInterview},
+		FromStage:     model.ApplicationStageScreening,
+		ToStage:       model.ApplicationStageInterview,
+		Note:          "Strong profile",
+	}
+	payload, _ := json.Marshal(change)
+	pending := []*model.OutboxEvent{{ID: 6, EventType: model.EventApplicationStageChanged, AggregateID: 5, CompanyID: 7, Payload: string(payload), OccurredAt: now}}
+	suite.outboxRepositoryMock.On("GetPending", now, 5, 10).Return(pending, nil).Once()
+	suite.outboxRepositoryMock.On("MarkSent", 6, now).Return(nil).Once()
+
+	delivered := suite.relay.Relay(context.Background(), now)
+
+	assert.Equal(suite.T(), 1, delivered)
+	assert.Equal(suite.T(), []events.DomainEvent{events.ApplicationStageChanged{
+		ID:            "6",
+		SchemaVersion: events.SchemaVersion,
+		ApplicationID: 5,
+		OfferID:       3,
+		CompanyID:     7,
+		CandidateID:   9,
+		FromStage:     model.ApplicationStageScreening,
+		ToStage:       model.ApplicationStageInterview,
+		Note:          "Strong profile",
+		Time:          now,
+	}}, publisher.Events())
+}
+
+func (suite *OutboxRelayUnitTestsSuite) TestOutboxRelay_RetryDelay_IsCapped() {
+	assert.Equal(suite.T(), time.Second, suite.relay.retryDelay(0))
+	assert.Equal(suite.T(), 8*time.Second, suite.relay.retryDelay(3))
+	assert.Equal(suite.T(), time.Minute, suite.relay.retryDelay(20))
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SavedOfferService struct {
+	SavedOfferRepo repository.ISavedOfferRepository
+	JobOfferRepo   repository.IJobOfferRepository
+	Logger         *logrus.Entry
+}
+
+type ISavedOfferService interface {
+	Save(int, int) (*dto.SavedOfferResponseDTO, error)
+	GetUsersSavedOffers(int, dto.PageRequestDTO) (*dto.SavedOfferPageDTO, error)
+	Delete(int, int) error
+}
+
+func NewSavedOfferService(savedOfferRepository repository.ISavedOfferRepository, jobOfferRepository repository.IJobOfferRepository, logger *logrus.Entry) ISavedOfferService {
+	return &SavedOfferService{
+		savedOfferRepository,
+		jobOfferRepository,
+		logger,
+	}
+}
+
+// Save bookmarks the offer for the user. Offers in the trash can not be saved.
+func (service *SavedOfferService) Save(userId int, jobOfferId int) (*dto.SavedOfferResponseDTO, error) {
+	if _, err := service.JobOfferRepo.GetById(jobOfferId); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Saving job offer with id %d for user %d", jobOfferId, userId))
+
+	savedOffer, err := service.SavedOfferRepo.Add(model.SavedOffer{UserID: userId, JobOfferID: jobOfferId})
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully saved job offer with id %d for user %d", jobOfferId, userId))
+	return mapper.SavedOfferToSavedOfferResponseDTO(savedOffer), nil
+}
+
+// GetUsersSavedOffers returns a page of the offers saved by the user, the most
+// recently saved first.
+func (service *SavedOfferService) GetUsersSavedOffers(userId int, pageDTO dto.PageRequestDTO) (*dto.SavedOfferPageDTO, error) {
+	page, err := toNewestFirstPageRequest(pageDTO)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting saved job offers from database for user %d", userId))
+	savedOffers, err := service.SavedOfferRepo.GetByUser(userId, page)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got saved job offers from database for user %d", userId))
+	return mapper.SavedOfferPageToSavedOfferPageDTO(savedOffers), nil
+}
+
+func (service *SavedOfferService) Delete(userId int, jobOfferId int) error {
+	service.Logger.Info(fmt.Sprintf("Removing saved job offer with id %d for user %d", jobOfferId, userId))
+
+	if err := service.SavedOfferRepo.Delete(userId, jobOfferId); err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully removed saved job offer with id %d for user %d", jobOfferId, userId))
+	return nil
+}
+
+// toNewestFirstPageRequest validates the request of a page of a list that is
+// ordered from the newest to the oldest item and can not be sorted otherwise.
+func toNewestFirstPageRequest(pageDTO dto.PageRequestDTO) (repository.PageRequest, error) {
+	if pageDTO.Sort != "" {
+		return repository.PageRequest{}, fmt.Errorf("%w: the list is ordered from the newest item and can not be sorted", ErrInvalidPageRequest)
+	}
+	pageDTO.Sort = "-" + repository.SortByID
+
+	return toPageRequest(pageDTO, false)
+}
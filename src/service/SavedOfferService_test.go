@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SavedOfferServiceUnitTestsSuite struct {
+	suite.Suite
+	savedOfferRepositoryMock *repository.SavedOfferRepositoryMock
+	offerRepositoryMock      *repository.JobOfferRepositoryMock
+	service                  ISavedOfferService
+}
+
+func TestSavedOfferServiceUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(SavedOfferServiceUnitTestsSuite))
+}
+
+func (suite *SavedOfferServiceUnitTestsSuite) SetupTest() {
+	suite.savedOfferRepositoryMock = new(repository.SavedOfferRepositoryMock)
+	suite.offerRepositoryMock = new(repository.JobOfferRepositoryMock)
+	suite.service = NewSavedOfferService(suite.savedOfferRepositoryMock, suite.offerRepositoryMock, utils.Logger())
+}
+
+func (suite *SavedOfferServiceUnitTestsSuite) TestSavedOfferService_Save_ReturnsOffer() {
+	offer := model.JobOffer{ID: 3, Position: "Developer", Status: model.JobOfferStatusPublished}
+	savedAt := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	suite.offerRepositoryMock.On("GetById", 3).Return(&offer, nil).Once()
+	suite.savedOfferRepositoryMock.On("Add", model.SavedOffer{UserID: 5, JobOfferID: 3}).
+		Return(&model.SavedOffer{ID: 1, UserID: 5, JobOfferID: 3, JobOffer: offer, CreatedAt: savedAt}, nil).Once()
+
+	savedOffer, err := suite.service.Save(5, 3)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), "Developer", savedOffer.Position)
+	assert.Equal(suite.T(), savedAt, savedOffer.SavedAt)
+	assert.True(suite.T(), savedOffer.Available)
+}
+
+func (suite *SavedOfferServiceUnitTestsSuite) TestSavedOfferService_Save_OfferNotFound() {
+	suite.offerRepositoryMock.On("GetById", 3).Return(nil, repository.ErrJobOfferNotFound).Once()
+
+	savedOffer, err := suite.service.Save(5, 3)
+
+	assert.Nil(suite.T(), savedOffer)
+	assert.True(suite.T(), errors.Is(err, repository.ErrJobOfferNotFound))
+	suite.savedOfferRepositoryMock.AssertNotCalled(suite.T(), "Add", model.SavedOffer{UserID: 5, JobOfferID: 3})
+}
+
+func (suite *SavedOfferServiceUnitTestsSuite) TestSavedOfferService_GetUsersSavedOffers_FlagsUnavailableOffers() {
+	deletedAt := time.Date(2022, 6, 2, 12, 0, 0, 0, time.UTC)
+	page := repository.PageRequest{Limit: defaultPageLimit, SortField: repository.SortByID, Descending: true}
+	suite.savedOfferRepositoryMock.On("GetByUser", 5, page).Return(&repository.SavedOfferPage{SavedOffers: []*model.SavedOffer{
+		{ID: 4, JobOfferID: 1, JobOffer: model.JobOffer{ID: 1, Status: model.JobOfferStatusPublished}},
+		{ID: 3, JobOfferID: 2, JobOffer: model.JobOffer{ID: 2, Status: model.JobOfferStatusClosed}},
+		{ID: 2, JobOfferID: 3, JobOffer: model.JobOffer{ID: 3, Status: model.JobOfferStatusExpired}},
+		{ID: 1, JobOfferID: 4, JobOffer: model.JobOffer{ID: 4, Status: model.JobOfferStatusPublished, DeletedAt: &deletedAt}},
+		{ID: 5, JobOfferID: 5},
+	}}, nil).Once()
+
+	savedOffers, err := suite.service.GetUsersSavedOffers(5, dto.PageRequestDTO{})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 5, len(savedOffers.Items))
+	assert.True(suite.T(), savedOffers.Items[0].Available)
+	assert.Equal(suite.T(), "closed", savedOffers.Items[1].UnavailableReason)
+	assert.Equal(suite.T(), "expired", savedOffers.Items[2].UnavailableReason)
+	assert.Equal(suite.T(), "deleted", savedOffers.Items[3].UnavailableReason)
+	assert.False(suite.T(), savedOffers.Items[3].Available)
+	assert.Equal(suite.T(), &deletedAt, savedOffers.Items[3].DeletedAt)
+	assert.Equal(suite.T(), 5, savedOffers.Items[4].ID)
+	assert.Equal(suite.T(), "deleted", savedOffers.Items[4].UnavailableReason)
+	assert.False(suite.T(), savedOffers.Items[4].Available)
+}
+
+func (suite *SavedOfferServiceUnitTestsSuite) TestSavedOfferService_GetUsersSavedOffers_ContinuesAfterCursor() {
+	cursor := repository.Cursor{SortField: repository.SortByID, Descending: true, Value: "9", ID: 9}
+	page := repository.PageRequest{Limit: 2, SortField: repository.SortByID, Descending: true, After: &cursor}
+	next := repository.Cursor{SortField: repository.SortByID, Descending: true, Value: "7", ID: 7}
+	suite.savedOfferRepositoryMock.On("GetByUser", 5, page).Return(&repository.SavedOfferPage{SavedOffers: []*model.SavedOffer{
+		{ID: 8, JobOffer: model.JobOffer{ID: 1}},
+		{ID: 7, JobOffer: model.JobOffer{ID: 2}},
+	}, HasMore: true, Next: &next}, nil).Once()
+
+	savedOffers, err := suite.service.GetUsersSavedOffers(5, dto.PageRequestDTO{Limit: 2, Cursor: cursor.Encode()})
+
+	assert.Nil(suite.T(), err)
+	assert.True(suite.T(), savedOffers.HasMore)
+	assert.Equal(suite.T(), next.Encode(), savedOffers.NextCursor)
+}
+
+func (suite *SavedOfferServiceUnitTestsSuite) TestSavedOfferService_GetUsersSavedOffers_SortFails() {
+	savedOffers, err := suite.service.GetUsersSavedOffers(5, dto.PageRequestDTO{Sort: "position"})
+
+	assert.Nil(suite.T(), savedOffers)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidPageRequest))
+}
+
+func (suite *SavedOfferServiceUnitTestsSuite) TestSavedOfferService_Delete_NotSaved() {
+	suite.savedOfferRepositoryMock.On("Delete", 5, 3).Return(repository.ErrSavedOfferNotFound).Once()
+
+	err := suite.service.Delete(5, 3)
+
+	assert.True(suite.T(), errors.Is(err, repository.ErrSavedOfferNotFound))
+}
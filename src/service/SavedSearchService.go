@@ -0,0 +1,115 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SavedSearchService struct {
+	SavedSearchRepo repository.ISavedSearchRepository
+	Logger          *logrus.Entry
+}
+
+type ISavedSearchService interface {
+	Add(int, *dto.SavedSearchRequestDTO) (*dto.SavedSearchResponseDTO, error)
+	GetUsersSavedSearches(int) ([]*dto.SavedSearchResponseDTO, error)
+	Delete(int, int) error
+}
+
+func NewSavedSearchService(savedSearchRepository repository.ISavedSearchRepository, logger *logrus.Entry) ISavedSearchService {
+	return &SavedSearchService{
+		savedSearchRepository,
+		logger,
+	}
+}
+
+// Add saves the search of the user after checking its filters are the ones
+// /jobOffers/search accepts.
+func (service *SavedSearchService) Add(userId int, searchDTO *dto.SavedSearchRequestDTO) (*dto.SavedSearchResponseDTO, error) {
+	if err := searchDTO.Validate(); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	for _, name := range []string{"q", "param"} {
+		if _, ok := searchDTO.Filters[name]; ok {
+			err := fmt.Errorf("%w: the free text of a saved search is given as its query", ErrInvalidSearchFilter)
+			service.Logger.Debug(err.Error())
+			return nil, err
+		}
+	}
+
+	filters, err := json.Marshal(searchDTO.Filters)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+	entity := model.SavedSearch{UserID: userId, Name: searchDTO.Name, Query: searchDTO.Query, Filters: string(filters)}
+
+	if _, err := savedSearchFilter(&entity); err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Adding saved search for user %d", userId))
+
+	addedEntity, err := service.SavedSearchRepo.Add(entity)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully added saved search in database with id %d", addedEntity.ID))
+	return mapper.SavedSearchToSavedSearchResponseDTO(&addedEntity), nil
+}
+
+func (service *SavedSearchService) GetUsersSavedSearches(userId int) ([]*dto.SavedSearchResponseDTO, error) {
+	service.Logger.Info(fmt.Sprintf("Getting saved searches from database for user %d", userId))
+	searches, err := service.SavedSearchRepo.GetByUser(userId)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got saved searches from database for user %d", userId))
+	res := make([]*dto.SavedSearchResponseDTO, len(searches))
+	for i := 0; i < len(searches); i++ {
+		res[i] = mapper.SavedSearchToSavedSearchResponseDTO(searches[i])
+	}
+	return res, nil
+}
+
+func (service *SavedSearchService) Delete(userId int, id int) error {
+	service.Logger.Info(fmt.Sprintf("Deleting saved search with id %d of user %d", id, userId))
+
+	if err := service.SavedSearchRepo.Delete(userId, id); err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully deleted saved search with id %d", id))
+	return nil
+}
+
+// savedSearchFilter turns the saved search into the filter of the search it
+// was saved from.
+func savedSearchFilter(search *model.SavedSearch) (repository.SearchFilter, error) {
+	filters := map[string][]string{}
+	if search.Filters != "" {
+		if err := json.Unmarshal([]byte(search.Filters), &filters); err != nil {
+			return repository.SearchFilter{}, err
+		}
+	}
+	if search.Query != "" {
+		filters["q"] = []string{search.Query}
+	}
+
+	return toSearchFilter(filters)
+}
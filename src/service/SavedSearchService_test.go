@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SavedSearchServiceUnitTestsSuite struct {
+	suite.Suite
+	savedSearchRepositoryMock *repository.SavedSearchRepositoryMock
+	service                   ISavedSearchService
+}
+
+func TestSavedSearchServiceUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(SavedSearchServiceUnitTestsSuite))
+}
+
+func (suite *SavedSearchServiceUnitTestsSuite) SetupTest() {
+	suite.savedSearchRepositoryMock = new(repository.SavedSearchRepositoryMock)
+	suite.service = NewSavedSearchService(suite.savedSearchRepositoryMock, utils.Logger())
+}
+
+func (suite *SavedSearchServiceUnitTestsSuite) TestSavedSearchService_Add_ReturnsSearch() {
+	searchDTO := dto.SavedSearchRequestDTO{Name: "Go jobs", Query: "backend", Filters: map[string][]string{"skills": {"go"}}}
+	search := model.SavedSearch{UserID: 5, Name: "Go jobs", Query: "backend", Filters: `{"skills":["go"]}`}
+	added := search
+	added.ID = 2
+	suite.savedSearchRepositoryMock.On("Add", search).Return(added, nil).Once()
+
+	res, err := suite.service.Add(5, &searchDTO)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 2, res.ID)
+	assert.Equal(suite.T(), map[string][]string{"skills": {"go"}}, res.Filters)
+}
+
+func (suite *SavedSearchServiceUnitTestsSuite) TestSavedSearchService_Add_UnknownFilterFails() {
+	searchDTO := dto.SavedSearchRequestDTO{Name: "Go jobs", Filters: map[string][]string{"salary": {"100"}}}
+
+	res, err := suite.service.Add(5, &searchDTO)
+
+	assert.Nil(suite.T(), res)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidSearchFilter))
+}
+
+func (suite *SavedSearchServiceUnitTestsSuite) TestSavedSearchService_Add_TextFilterFails() {
+	searchDTO := dto.SavedSearchRequestDTO{Name: "Go jobs", Filters: map[string][]string{"q": {"backend"}}}
+
+	res, err := suite.service.Add(5, &searchDTO)
+
+	assert.Nil(suite.T(), res)
+	assert.True(suite.T(), errors.Is(err, ErrInvalidSearchFilter))
+}
+
+func (suite *SavedSearchServiceUnitTestsSuite) TestSavedSearchService_Delete_NotFound() {
+	suite.savedSearchRepositoryMock.On("Delete", 5, 2).Return(repository.ErrSavedSearchNotFound).Once()
+
+	err := suite.service.Delete(5, 2)
+
+	assert.True(suite.T(), errors.Is(err, repository.ErrSavedSearchNotFound))
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"jobs-ms/src/dto"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SkillService struct {
+	SkillRepo repository.ISkillRepository
+	Logger    *logrus.Entry
+}
+
+type ISkillService interface {
+	GetAll() ([]*dto.SkillResponseDTO, error)
+}
+
+func NewSkillService(skillRepository repository.ISkillRepository, logger *logrus.Entry) ISkillService {
+	return &SkillService{
+		skillRepository,
+		logger,
+	}
+}
+
+func (service *SkillService) GetAll() ([]*dto.SkillResponseDTO, error) {
+	service.Logger.Info("Getting skills from database")
+	skills, err := service.SkillRepo.GetAll()
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	res := make([]*dto.SkillResponseDTO, len(skills))
+	for i := 0; i < len(skills); i++ {
+		res[i] = mapper.SkillToSkillResponseDTO(skills[i])
+	}
+
+	service.Logger.Info("Successfully got skills from database")
+	return res, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SkillServiceUnitTestsSuite struct {
+	suite.Suite
+	skillRepositoryMock *repository.SkillRepositoryMock
+	service             ISkillService
+}
+
+func TestSkillServiceUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(SkillServiceUnitTestsSuite))
+}
+
+func (suite *SkillServiceUnitTestsSuite) SetupSuite() {
+	suite.skillRepositoryMock = new(repository.SkillRepositoryMock)
+	suite.service = NewSkillService(suite.skillRepositoryMock, utils.Logger())
+}
+
+func (suite *SkillServiceUnitTestsSuite) TestSkillService_GetAll_ReturnsSkillsWithOfferCount() {
+	skills := []*model.Skill{{ID: 1, Name: "go", OfferCount: 3}, {ID: 2, Name: "sql", OfferCount: 1}}
+	suite.skillRepositoryMock.On("GetAll").Return(skills, nil).Once()
+
+	result, err := suite.service.GetAll()
+
+	assert.Equal(suite.T(), nil, err)
+	assert.Equal(suite.T(), 2, len(result))
+	assert.Equal(suite.T(), "go", result[0].Name)
+	assert.Equal(suite.T(), 3, result[0].OfferCount)
+}
+
+func (suite *SkillServiceUnitTestsSuite) TestSkillService_GetAll_RepositoryFails() {
+	suite.skillRepositoryMock.On("GetAll").Return([]*model.Skill{}, errors.New("error")).Once()
+
+	result, err := suite.service.GetAll()
+
+	assert.Nil(suite.T(), result)
+	assert.NotNil(suite.T(), err)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TrashPurger periodically removes the job offers that have been in the
+// trash for longer than the retention period.
+type TrashPurger struct {
+	Service   IJobOfferService
+	Retention time.Duration
+	Interval  time.Duration
+	Logger    *logrus.Entry
+}
+
+func (purger *TrashPurger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(purger.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				purger.Purge(time.Now())
+			}
+		}
+	}()
+}
+
+func (purger *TrashPurger) Purge(now time.Time) {
+	if _, err := purger.Service.PurgeTrash(now.Add(-purger.Retention)); err != nil {
+		purger.Logger.Debug(err.Error())
+	}
+}
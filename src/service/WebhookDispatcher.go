@@ -0,0 +1,123 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"jobs-ms/src/events"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookSignatureHeader carries the hex encoded HMAC-SHA256 of the body of a
+// delivery, keyed with the secret of the subscription, as "sha256=<hex>".
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+// WebhookDispatcher periodically posts the pending webhook deliveries. Failed
+// deliveries are retried with an exponentially growing delay and marked as
+// failed after MaxAttempts attempts. Client should be a NewWebhookClient, so
+// that deliveries are never posted to internal addresses.
+type WebhookDispatcher struct {
+	WebhookRepo   repository.IWebhookRepository
+	Client        *http.Client
+	Interval      time.Duration
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	MaxAttempts   int
+	BatchSize     int
+	Logger        *logrus.Entry
+}
+
+func (dispatcher *WebhookDispatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(dispatcher.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				dispatcher.Dispatch(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// Dispatch posts the deliveries that are due at the given time and returns
+// how many were delivered.
+func (dispatcher *WebhookDispatcher) Dispatch(ctx context.Context, now time.Time) int {
+	deliveries, err := dispatcher.WebhookRepo.GetPendingDeliveries(now, dispatcher.BatchSize)
+	if err != nil {
+		dispatcher.Logger.Debug(err.Error())
+		return 0
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		delivery.Attempts++
+		status, err := dispatcher.post(ctx, delivery)
+		delivery.ResponseStatus = status
+
+		switch {
+		case err == nil:
+			delivery.Status = model.WebhookDeliveryDelivered
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+			delivered++
+		case delivery.Attempts >= dispatcher.MaxAttempts:
+			dispatcher.Logger.Debug(fmt.Sprintf("Giving up webhook delivery with id %d: %s", delivery.ID, err.Error()))
+			delivery.Status = model.WebhookDeliveryFailed
+			delivery.LastError = err.Error()
+		default:
+			dispatcher.Logger.Debug(fmt.Sprintf("Error happened during webhook delivery with id %d: %s", delivery.ID, err.Error()))
+			delivery.LastError = err.Error()
+			delivery.NextAttemptAt = now.Add(backoff(dispatcher.RetryDelay, dispatcher.MaxRetryDelay, delivery.Attempts-1))
+		}
+
+		if err := dispatcher.WebhookRepo.UpdateDelivery(delivery); err != nil {
+			dispatcher.Logger.Debug(err.Error())
+		}
+	}
+
+	if delivered > 0 {
+		dispatcher.Logger.Info(fmt.Sprintf("Successfully delivered %d webhooks", delivered))
+	}
+	return delivered
+}
+
+func (dispatcher *WebhookDispatcher) post(ctx context.Context, delivery *model.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", delivery.Subscription.URL, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("content-type", events.CloudEventsContentType)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(delivery.Subscription.Secret, body))
+
+	resp, err := dispatcher.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("Webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the value of the signature header for the body.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
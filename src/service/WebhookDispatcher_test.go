@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"io/ioutil"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookDispatcherUnitTestsSuite struct {
+	suite.Suite
+	webhookRepositoryMock *repository.WebhookRepositoryMock
+	partnerServer         *httptest.Server
+	signature             string
+	body                  []byte
+	status                int
+	dispatcher            *WebhookDispatcher
+}
+
+func TestWebhookDispatcherUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(WebhookDispatcherUnitTestsSuite))
+}
+
+func (suite *WebhookDispatcherUnitTestsSuite) SetupTest() {
+	suite.status = http.StatusOK
+	suite.partnerServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.signature = r.Header.Get(WebhookSignatureHeader)
+		suite.body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(suite.status)
+	}))
+
+	suite.webhookRepositoryMock = new(repository.WebhookRepositoryMock)
+	suite.dispatcher = &WebhookDispatcher{
+		WebhookRepo:   suite.webhookRepositoryMock,
+		Client:        suite.partnerServer.Client(),
+		Interval:      time.Second,
+		RetryDelay:    time.Minute,
+		MaxRetryDelay: time.Hour,
+		MaxAttempts:   3,
+		BatchSize:     10,
+		Logger:        utils.Logger(),
+	}
+}
+
+func (suite *WebhookDispatcherUnitTestsSuite) TearDownTest() {
+	suite.partnerServer.Close()
+}
+
+func (suite *WebhookDispatcherUnitTestsSuite) delivery(attempts int) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		ID:           7,
+		Subscription: model.WebhookSubscription{ID: 3, URL: suite.partnerServer.URL, Secret: "secret"},
+		EventID:      "12",
+		Payload:      `{"specversion":"1.0"}`,
+		Status:       model.WebhookDeliveryPending,
+		Attempts:     attempts,
+	}
+}
+
+func (suite *WebhookDispatcherUnitTestsSuite) TestWebhookDispatcher_Dispatch_SignsAndMarksDelivered() {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	suite.webhookRepositoryMock.On("GetPendingDeliveries", now, 10).Return([]*model.WebhookDelivery{suite.delivery(0)}, nil).Once()
+	suite.webhookRepositoryMock.On("UpdateDelivery", mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+		return delivery.Status == model.WebhookDeliveryDelivered && delivery.Attempts == 1 && delivery.ResponseStatus == 200 && *delivery.DeliveredAt == now
+	})).Return(nil).Once()
+
+	delivered := suite.dispatcher.Dispatch(context.Background(), now)
+
+	assert.Equal(suite.T(), 1, delivered)
+	assert.Equal(suite.T(), `{"specversion":"1.0"}`, string(suite.body))
+	assert.Equal(suite.T(), "sha256=dc46983557fea127b43af721467eb9b3fde2338fe3e14f51952aa8478c13d355", SignWebhook("secret", []byte("body")))
+	assert.Equal(suite.T(), SignWebhook("secret", suite.body), suite.signature)
+	suite.webhookRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookDispatcherUnitTestsSuite) TestWebhookDispatcher_Dispatch_RetriesWithBackoff() {
+	suite.status = http.StatusInternalServerError
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	suite.webhookRepositoryMock.On("GetPendingDeliveries", now, 10).Return([]*model.WebhookDelivery{suite.delivery(1)}, nil).Once()
+	suite.webhookRepositoryMock.On("UpdateDelivery", mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+		return delivery.Status == model.WebhookDeliveryPending && delivery.Attempts == 2 && delivery.ResponseStatus == 500 &&
+			delivery.NextAttemptAt == now.Add(2*time.Minute) && delivery.LastError == "Webhook responded with status 500"
+	})).Return(nil).Once()
+
+	delivered := suite.dispatcher.Dispatch(context.Background(), now)
+
+	assert.Equal(suite.T(), 0, delivered)
+	suite.webhookRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookDispatcherUnitTestsSuite) TestWebhookDispatcher_Dispatch_GivesUpAfterMaxAttempts() {
+	suite.status = http.StatusGone
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	suite.webhookRepositoryMock.On("GetPendingDeliveries", now, 10).Return([]*model.WebhookDelivery{suite.delivery(2)}, nil).Once()
+	suite.webhookRepositoryMock.On("UpdateDelivery", mock.MatchedBy(func(delivery *model.WebhookDelivery) bool {
+		return delivery.Status == model.WebhookDeliveryFailed && delivery.Attempts == 3
+	})).Return(nil).Once()
+
+	delivered := suite.dispatcher.Dispatch(context.Background(), now)
+
+	assert.Equal(suite.T(), 0, delivered)
+	suite.webhookRepositoryMock.AssertExpectations(suite.T())
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/events"
+	"jobs-ms/src/mapper"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+)
+
+type WebhookService struct {
+	WebhookRepo repository.IWebhookRepository
+	MemberRepo  repository.ICompanyMemberRepository
+	AuditRepo   repository.IAuditRepository
+	Logger      *logrus.Entry
+}
+
+type IWebhookService interface {
+	Add(context.Context, *dto.WebhookRequestDTO) (*dto.WebhookResponseDTO, error)
+	Update(context.Context, int, *dto.WebhookRequestDTO) (*dto.WebhookResponseDTO, error)
+	GetById(context.Context, int) (*dto.WebhookResponseDTO, error)
+	GetCompanysWebhooks(context.Context, int) ([]*dto.WebhookResponseDTO, error)
+	Delete(context.Context, int) error
+	GetDeliveries(context.Context, int, string, int) ([]*dto.WebhookDeliveryResponseDTO, error)
+	Publish(context.Context, events.DomainEvent) error
+}
+
+func NewWebhookService(webhookRepository repository.IWebhookRepository, memberRepository repository.ICompanyMemberRepository, auditRepository repository.IAuditRepository, logger *logrus.Entry) IWebhookService {
+	return &WebhookService{
+		webhookRepository,
+		memberRepository,
+		auditRepository,
+		logger,
+	}
+}
+
+// Add creates the subscription with a new secret. The secret is returned only
+// in this response and is needed to verify the signatures of deliveries.
+func (service *WebhookService) Add(ctx context.Context, webhookDTO *dto.WebhookRequestDTO) (*dto.WebhookResponseDTO, error) {
+	if err := service.validate(webhookDTO); err != nil {
+		return nil, err
+	}
+
+	if err := service.authorize(ctx, model.AuditActionCreateWebhook, webhookDTO.CompanyID); err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	entity := mapper.WebhookRequestDTOToWebhookSubscription(webhookDTO)
+	entity.Secret = secret
+
+	service.Logger.Info(fmt.Sprintf("Adding new webhook subscription in database for company %d", entity.CompanyID))
+
+	addedEntity, err := service.WebhookRepo.Add(*entity)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully added new webhook subscription in database with id %d", addedEntity.ID))
+	res := mapper.WebhookSubscriptionToWebhookResponseDTO(&addedEntity)
+	res.Secret = addedEntity.Secret
+	return res, nil
+}
+
+// Update replaces the URL, event types and activity of the subscription,
+// keeping its company and secret.
+func (service *WebhookService) Update(ctx context.Context, id int, webhookDTO *dto.WebhookRequestDTO) (*dto.WebhookResponseDTO, error) {
+	if err := service.validate(webhookDTO); err != nil {
+		return nil, err
+	}
+
+	existing, err := service.getOwnedWebhook(ctx, model.AuditActionUpdateWebhook, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.CompanyID != webhookDTO.CompanyID {
+		err := fmt.Errorf("%w: webhook subscription with id %d belongs to another company", repository.ErrWebhookNotFound, id)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	entity := mapper.WebhookRequestDTOToWebhookSubscription(webhookDTO)
+	entity.ID = id
+	entity.Secret = existing.Secret
+	entity.CreatedAt = existing.CreatedAt
+
+	service.Logger.Info(fmt.Sprintf("Updating webhook subscription in database with id %d", id))
+
+	updatedEntity, err := service.WebhookRepo.Update(*entity)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully updated webhook subscription in database with id %d", id))
+	return mapper.WebhookSubscriptionToWebhookResponseDTO(&updatedEntity), nil
+}
+
+func (service *WebhookService) GetById(ctx context.Context, id int) (*dto.WebhookResponseDTO, error) {
+	service.Logger.Info(fmt.Sprintf("Getting webhook subscription from database with id %d", id))
+	subscription, err := service.getOwnedWebhook(ctx, model.AuditActionListWebhooks, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got webhook subscription from database with id %d", id))
+	return mapper.WebhookSubscriptionToWebhookResponseDTO(subscription), nil
+}
+
+func (service *WebhookService) GetCompanysWebhooks(ctx context.Context, companyId int) ([]*dto.WebhookResponseDTO, error) {
+	if err := service.authorize(ctx, model.AuditActionListWebhooks, companyId); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting webhook subscriptions from database for company %d", companyId))
+	subscriptions, err := service.WebhookRepo.GetByCompany(companyId)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	res := make([]*dto.WebhookResponseDTO, len(subscriptions))
+	for i := 0; i < len(subscriptions); i++ {
+		res[i] = mapper.WebhookSubscriptionToWebhookResponseDTO(subscriptions[i])
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got webhook subscriptions from database for company %d", companyId))
+	return res, nil
+}
+
+func (service *WebhookService) Delete(ctx context.Context, id int) error {
+	if _, err := service.getOwnedWebhook(ctx, model.AuditActionDeleteWebhook, id); err != nil {
+		return err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Deleting webhook subscription from database with id %d", id))
+	err := service.WebhookRepo.Delete(id)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully deleted webhook subscription from database with id %d", id))
+	return nil
+}
+
+// GetDeliveries returns the delivery log of the subscription, newest first.
+func (service *WebhookService) GetDeliveries(ctx context.Context, id int, status string, limit int) ([]*dto.WebhookDeliveryResponseDTO, error) {
+	if limit == 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit < 0 || limit > maxDeliveryLimit {
+		err := fmt.Errorf("%w: limit should be between 1 and %d", ErrInvalidPageRequest, maxDeliveryLimit)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+	if status != "" && status != model.WebhookDeliveryPending && status != model.WebhookDeliveryDelivered && status != model.WebhookDeliveryFailed {
+		err := fmt.Errorf("%w: unknown delivery status %s", ErrInvalidSearchFilter, status)
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if _, err := service.getOwnedWebhook(ctx, model.AuditActionListWebhookDeliveries, id); err != nil {
+		return nil, err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Getting deliveries from database for webhook subscription with id %d", id))
+	deliveries, err := service.WebhookRepo.GetDeliveries(id, status, limit)
+
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	res := make([]*dto.WebhookDeliveryResponseDTO, len(deliveries))
+	for i := 0; i < len(deliveries); i++ {
+		res[i] = mapper.WebhookDeliveryToWebhookDeliveryResponseDTO(deliveries[i])
+	}
+
+	service.Logger.Info(fmt.Sprintf("Successfully got deliveries from database for webhook subscription with id %d", id))
+	return res, nil
+}
+
+// Publish queues a delivery of the event for every active subscription of
+// its company to its type. The deliveries are posted by the WebhookDispatcher.
+func (service *WebhookService) Publish(ctx context.Context, event events.DomainEvent) error {
+	subscriptions, err := service.WebhookRepo.GetSubscribers(event.Company(), event.EventType())
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	cloudEvent, err := events.ToCloudEvent(event)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+	payload, err := cloudEvent.Structured()
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]*model.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = &model.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.EventID(),
+			EventType:      event.EventType(),
+			Payload:        string(payload),
+			Status:         model.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+		}
+	}
+
+	if err := service.WebhookRepo.AddDeliveries(deliveries); err != nil {
+		service.Logger.Debug(err.Error())
+		return err
+	}
+
+	service.Logger.Info(fmt.Sprintf("Queued %d webhook deliveries of event %s", len(deliveries), event.EventID()))
+	return nil
+}
+
+func (service *WebhookService) validate(webhookDTO *dto.WebhookRequestDTO) error {
+	err := webhookDTO.Validate()
+	if err == nil {
+		err = validateWebhookURL(webhookDTO.URL)
+	}
+	if err != nil {
+		service.Logger.Debug(err.Error())
+	}
+	return err
+}
+
+// getOwnedWebhook returns the subscription when the caller in ctx may manage
+// the webhooks of its company.
+func (service *WebhookService) getOwnedWebhook(ctx context.Context, action string, id int) (*model.WebhookSubscription, error) {
+	subscription, err := service.WebhookRepo.GetById(id)
+	if err != nil {
+		service.Logger.Debug(err.Error())
+		return nil, err
+	}
+
+	if err := service.authorize(ctx, action, subscription.CompanyID); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (service *WebhookService) authorize(ctx context.Context, action string, companyId int) error {
+	authorizer := companyAuthorizer{MemberRepo: service.MemberRepo, AuditRepo: service.AuditRepo, Logger: service.Logger}
+	return authorizer.authorize(ctx, action, companyId, nil)
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"jobs-ms/src/auth"
+	"jobs-ms/src/dto"
+	"jobs-ms/src/events"
+	"jobs-ms/src/model"
+	"jobs-ms/src/repository"
+	"jobs-ms/src/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookServiceUnitTestsSuite struct {
+	suite.Suite
+	webhookRepositoryMock *repository.WebhookRepositoryMock
+	memberRepositoryMock  *repository.CompanyMemberRepositoryMock
+	auditRepositoryMock   *repository.AuditRepositoryMock
+	service               IWebhookService
+	ctx                   context.Context
+}
+
+func TestWebhookServiceUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceUnitTestsSuite))
+}
+
+func (suite *WebhookServiceUnitTestsSuite) SetupTest() {
+	suite.webhookRepositoryMock = new(repository.WebhookRepositoryMock)
+	suite.memberRepositoryMock = new(repository.CompanyMemberRepositoryMock)
+	suite.auditRepositoryMock = new(repository.AuditRepositoryMock)
+	suite.service = NewWebhookService(suite.webhookRepositoryMock, suite.memberRepositoryMock, suite.auditRepositoryMock, utils.Logger())
+	suite.ctx = auth.NewContext(context.Background(), &auth.Identity{UserID: 1, Roles: []string{auth.RolePlatformAdmin}})
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Add_GeneratesSecret() {
+	webhookDTO := dto.WebhookRequestDTO{CompanyID: 1, URL: "https://example.com/hooks", EventTypes: []string{"JobOfferCreated"}}
+	suite.webhookRepositoryMock.On("Add", mock.MatchedBy(func(subscription model.WebhookSubscription) bool {
+		return subscription.CompanyID == 1 && subscription.Active && len(subscription.Secret) == 64
+	})).Return(model.WebhookSubscription{ID: 3, CompanyID: 1, URL: "https://example.com/hooks", Secret: "secret", EventTypes: []string{"JobOfferCreated"}, Active: true}, nil).Once()
+
+	webhook, err := suite.service.Add(suite.ctx, &webhookDTO)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 3, webhook.ID)
+	assert.Equal(suite.T(), []string{"JobOfferCreated"}, webhook.EventTypes)
+	assert.Equal(suite.T(), "secret", webhook.Secret)
+	suite.webhookRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Add_UnknownEventTypeFails() {
+	webhookDTO := dto.WebhookRequestDTO{CompanyID: 1, URL: "https://example.com/hooks", EventTypes: []string{"JobOfferArchived"}}
+
+	webhook, err := suite.service.Add(suite.ctx, &webhookDTO)
+
+	assert.Nil(suite.T(), webhook)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Add_InvalidURLFails() {
+	webhookDTO := dto.WebhookRequestDTO{CompanyID: 1, URL: "not a url", EventTypes: []string{"JobOfferCreated"}}
+
+	webhook, err := suite.service.Add(suite.ctx, &webhookDTO)
+
+	assert.Nil(suite.T(), webhook)
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Add_InternalURLFails() {
+	urls := []string{
+		"http://example.com/hooks",
+		"https://localhost/hooks",
+		"https://127.0.0.1/hooks",
+		"https://10.0.0.8/hooks",
+		"https://192.168.1.1:8443/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hooks",
+	}
+
+	for _, url := range urls {
+		webhookDTO := dto.WebhookRequestDTO{CompanyID: 1, URL: url, EventTypes: []string{"JobOfferCreated"}}
+
+		webhook, err := suite.service.Add(suite.ctx, &webhookDTO)
+
+		assert.Nil(suite.T(), webhook, url)
+		assert.ErrorIs(suite.T(), err, ErrInvalidWebhookURL, url)
+	}
+	suite.webhookRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Add_NonMemberForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 30, Roles: []string{auth.RoleCompanyAdmin}})
+	webhookDTO := dto.WebhookRequestDTO{CompanyID: 8, URL: "https://example.com/hooks", EventTypes: []string{"ApplicationStageChanged"}}
+	userId := 30
+	entry := model.AuditEntry{UserID: &userId, CompanyID: 8, Action: model.AuditActionCreateWebhook, Reason: "user 30 is not a member of company 8"}
+	suite.memberRepositoryMock.On("IsMember", 30, 8).Return(false, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	webhook, err := suite.service.Add(ctx, &webhookDTO)
+
+	assert.Nil(suite.T(), webhook)
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.webhookRepositoryMock.AssertNotCalled(suite.T(), "Add", mock.Anything)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Delete_OtherCompanysWebhookForbidden() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 30, Roles: []string{auth.RoleCompanyAdmin}})
+	userId := 30
+	entry := model.AuditEntry{UserID: &userId, CompanyID: 8, Action: model.AuditActionDeleteWebhook, Reason: "user 30 is not a member of company 8"}
+	suite.webhookRepositoryMock.On("GetById", 3).Return(&model.WebhookSubscription{ID: 3, CompanyID: 8}, nil).Once()
+	suite.memberRepositoryMock.On("IsMember", 30, 8).Return(false, nil).Once()
+	suite.auditRepositoryMock.On("Add", entry).Return(nil).Once()
+
+	err := suite.service.Delete(ctx, 3)
+
+	assert.ErrorIs(suite.T(), err, ErrForbidden)
+	suite.webhookRepositoryMock.AssertNotCalled(suite.T(), "Delete", 3)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_GetCompanysWebhooks_MemberAllowed() {
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: 31, Roles: []string{auth.RoleCompanyAdmin}})
+	suite.memberRepositoryMock.On("IsMember", 31, 8).Return(true, nil).Once()
+	suite.webhookRepositoryMock.On("GetByCompany", 8).Return([]*model.WebhookSubscription{{ID: 3, CompanyID: 8}}, nil).Once()
+
+	webhooks, err := suite.service.GetCompanysWebhooks(ctx, 8)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(webhooks))
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Update_KeepsSecret() {
+	active := false
+	webhookDTO := dto.WebhookRequestDTO{CompanyID: 1, URL: "https://example.com/new", EventTypes: []string{"JobOfferDeleted"}, Active: &active}
+	existing := model.WebhookSubscription{ID: 3, CompanyID: 1, URL: "https://example.com/hooks", Secret: "secret", EventTypes: []string{"JobOfferCreated"}, Active: true}
+	updated := model.WebhookSubscription{ID: 3, CompanyID: 1, URL: "https://example.com/new", Secret: "secret", EventTypes: []string{"JobOfferDeleted"}, Active: false}
+	suite.webhookRepositoryMock.On("GetById", 3).Return(&existing, nil).Once()
+	suite.webhookRepositoryMock.On("Update", updated).Return(updated, nil).Once()
+
+	webhook, err := suite.service.Update(suite.ctx, 3, &webhookDTO)
+
+	assert.Nil(suite.T(), err)
+	assert.False(suite.T(), webhook.Active)
+	assert.Equal(suite.T(), "", webhook.Secret)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Update_OtherCompanysWebhook() {
+	webhookDTO := dto.WebhookRequestDTO{CompanyID: 2, URL: "https://example.com/new", EventTypes: []string{"JobOfferDeleted"}}
+	existing := model.WebhookSubscription{ID: 3, CompanyID: 1}
+	suite.webhookRepositoryMock.On("GetById", 3).Return(&existing, nil).Once()
+
+	webhook, err := suite.service.Update(suite.ctx, 3, &webhookDTO)
+
+	assert.Nil(suite.T(), webhook)
+	assert.ErrorIs(suite.T(), err, repository.ErrWebhookNotFound)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_GetDeliveries_UnknownStatus() {
+	deliveries, err := suite.service.GetDeliveries(suite.ctx, 3, "lost", 0)
+
+	assert.Nil(suite.T(), deliveries)
+	assert.ErrorIs(suite.T(), err, ErrInvalidSearchFilter)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_GetDeliveries_DefaultLimit() {
+	suite.webhookRepositoryMock.On("GetById", 3).Return(&model.WebhookSubscription{ID: 3}, nil).Once()
+	suite.webhookRepositoryMock.On("GetDeliveries", 3, "failed", 50).Return([]*model.WebhookDelivery{
+		{ID: 9, EventID: "12", EventType: "JobOfferCreated", Status: model.WebhookDeliveryFailed, Attempts: 8, ResponseStatus: 500},
+	}, nil).Once()
+
+	deliveries, err := suite.service.GetDeliveries(suite.ctx, 3, "failed", 0)
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(deliveries))
+	assert.Equal(suite.T(), 500, deliveries[0].ResponseStatus)
+	assert.Nil(suite.T(), deliveries[0].NextAttemptAt)
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Publish_QueuesDeliveryPerSubscriber() {
+	event, _ := events.NewJobOfferEvent(model.EventJobOfferClosed, events.JobOfferEvent{ID: "12", OfferID: 5, CompanyID: 1, Time: time.Now()})
+	subscriptions := []*model.WebhookSubscription{{ID: 3, CompanyID: 1}, {ID: 4, CompanyID: 1}}
+	suite.webhookRepositoryMock.On("GetSubscribers", 1, model.EventJobOfferClosed).Return(subscriptions, nil).Once()
+	suite.webhookRepositoryMock.On("AddDeliveries", mock.MatchedBy(func(deliveries []*model.WebhookDelivery) bool {
+		var cloudEvent events.CloudEvent
+		json.Unmarshal([]byte(deliveries[0].Payload), &cloudEvent)
+		return len(deliveries) == 2 &&
+			deliveries[0].SubscriptionID == 3 && deliveries[1].SubscriptionID == 4 &&
+			deliveries[0].EventID == "12" && deliveries[0].Status == model.WebhookDeliveryPending &&
+			cloudEvent.Type == "jobs.joboffer.JobOfferClosed"
+	})).Return(nil).Once()
+
+	err := suite.service.Publish(context.Background(), event)
+
+	assert.Nil(suite.T(), err)
+	suite.webhookRepositoryMock.AssertExpectations(suite.T())
+}
+
+func (suite *WebhookServiceUnitTestsSuite) TestWebhookService_Publish_NoSubscribers() {
+	event, _ := events.NewJobOfferEvent(model.EventJobOfferCreated, events.JobOfferEvent{ID: "13", OfferID: 5, CompanyID: 2, Time: time.Now()})
+	suite.webhookRepositoryMock.On("GetSubscribers", 2, model.EventJobOfferCreated).Return([]*model.WebhookSubscription{}, nil).Once()
+
+	err := suite.service.Publish(context.Background(), event)
+
+	assert.Nil(suite.T(), err)
+	suite.webhookRepositoryMock.AssertNotCalled(suite.T(), "AddDeliveries", mock.Anything)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var ErrInvalidWebhookURL = errors.New("Invalid webhook URL")
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not reachable
+// from the internet either.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// validateWebhookURL checks that webhooks can be posted to the URL: it has to
+// be an https URL whose host is not a loopback, private or link-local address.
+// Host names are resolved only when a delivery is posted, see
+// NewWebhookClient.
+func validateWebhookURL(rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidWebhookURL, err.Error())
+	}
+	if target.Scheme != "https" {
+		return fmt.Errorf("%w: %s is not an https URL", ErrInvalidWebhookURL, rawURL)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(target.Hostname(), "."))
+	if host == "" {
+		return fmt.Errorf("%w: %s has no host", ErrInvalidWebhookURL, rawURL)
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s is a loopback host", ErrInvalidWebhookURL, host)
+	}
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s is not a public address", ErrInvalidWebhookURL, host)
+	}
+	return nil
+}
+
+// isPublicIP tells whether the address can be reached from the internet.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// NewWebhookClient returns the client the WebhookDispatcher posts deliveries
+// with. It sends requests only to https URLs, connects only to public
+// addresses, whatever the host name of the URL resolves to, and does not
+// follow redirects.
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialPublicAddress}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: webhookTransport{transport},
+		Timeout:   timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookTransport refuses requests to URLs webhooks can not be posted to.
+type webhookTransport struct {
+	http.RoundTripper
+}
+
+func (transport webhookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := validateWebhookURL(req.URL.String()); err != nil {
+		return nil, err
+	}
+	return transport.RoundTripper.RoundTrip(req)
+}
+
+// dialPublicAddress refuses connections to addresses that are not public. It
+// runs after the host name is resolved, so names resolving to internal
+// addresses are refused too.
+func dialPublicAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s is not a public address", ErrInvalidWebhookURL, host)
+	}
+	return nil
+}
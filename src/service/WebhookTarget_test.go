@@ -0,0 +1,81 @@
+package service
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookTargetUnitTestsSuite struct {
+	suite.Suite
+}
+
+func TestWebhookTargetUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(WebhookTargetUnitTestsSuite))
+}
+
+func (suite *WebhookTargetUnitTestsSuite) TestValidateWebhookURL() {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://example.com/hooks", true},
+		{"https://example.com:8443/hooks?token=1", true},
+		{"https://8.8.8.8/hooks", true},
+		{"http://example.com/hooks", false},
+		{"ftp://example.com/hooks", false},
+		{"https:///hooks", false},
+		{"https://LOCALHOST./hooks", false},
+		{"https://api.localhost/hooks", false},
+		{"https://127.0.0.1/hooks", false},
+		{"https://0.0.0.0/hooks", false},
+		{"https://10.1.2.3/hooks", false},
+		{"https://172.16.0.1/hooks", false},
+		{"https://192.168.0.1/hooks", false},
+		{"https://100.64.0.1/hooks", false},
+		{"https://169.254.169.254/latest/meta-data", false},
+		{"https://[::1]/hooks", false},
+		{"https://[fd00::1]/hooks", false},
+		{"https://[fe80::1]/hooks", false},
+		{"https://[::ffff:127.0.0.1]/hooks", false},
+	}
+
+	for _, test := range tests {
+		err := validateWebhookURL(test.url)
+
+		if test.valid {
+			assert.Nil(suite.T(), err, test.url)
+		} else {
+			assert.ErrorIs(suite.T(), err, ErrInvalidWebhookURL, test.url)
+		}
+	}
+}
+
+func (suite *WebhookTargetUnitTestsSuite) TestDialPublicAddress() {
+	assert.Nil(suite.T(), dialPublicAddress("tcp", "93.184.216.34:443", nil))
+	assert.Nil(suite.T(), dialPublicAddress("tcp6", "[2606:2800:220:1::1]:443", nil))
+	assert.ErrorIs(suite.T(), dialPublicAddress("tcp", "127.0.0.1:443", nil), ErrInvalidWebhookURL)
+	assert.ErrorIs(suite.T(), dialPublicAddress("tcp", "169.254.169.254:443", nil), ErrInvalidWebhookURL)
+	assert.ErrorIs(suite.T(), dialPublicAddress("tcp6", "[::1]:443", nil), ErrInvalidWebhookURL)
+}
+
+func (suite *WebhookTargetUnitTestsSuite) TestNewWebhookClient_RefusesInternalTargets() {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	client := NewWebhookClient(time.Second)
+
+	for _, url := range []string{server.URL, "https://localhost:" + port, "http://example.com/hooks"} {
+		_, err := client.Post(url, "application/json", nil)
+
+		assert.True(suite.T(), errors.Is(err, ErrInvalidWebhookURL), url)
+	}
+}
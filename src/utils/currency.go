@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	SalaryPeriodHourly  = "hourly"
+	SalaryPeriodMonthly = "monthly"
+	SalaryPeriodYearly  = "yearly"
+)
+
+// SalaryPeriodsPerYear is how many times a salary of every period is paid in a
+// year, assuming 40 hour weeks.
+var SalaryPeriodsPerYear = map[string]float64{
+	SalaryPeriodHourly:  2080,
+	SalaryPeriodMonthly: 12,
+	SalaryPeriodYearly:  1,
+}
+
+// BaseCurrency is the currency salaries are converted to for comparison.
+const BaseCurrency = "EUR"
+
+// CurrencyRates is the value of one unit of every currency salaries can be
+// compared in, in BaseCurrency. It can be replaced at startup with rates
+// parsed by ParseCurrencyRates.
+var CurrencyRates = map[string]float64{
+	"EUR": 1,
+	"USD": 0.92,
+	"GBP": 1.16,
+	"CHF": 1.04,
+	"RSD": 0.0085,
+	"BAM": 0.51,
+	"HUF": 0.0025,
+	"PLN": 0.23,
+	"CZK": 0.04,
+	"SEK": 0.087,
+	"NOK": 0.086,
+	"DKK": 0.134,
+	"CAD": 0.68,
+	"AUD": 0.61,
+	"JPY": 0.0062,
+}
+
+// currencyCodes are the active ISO 4217 currency codes salaries can be paid
+// in. Precious metals, units of account and the testing and no currency codes
+// are left out.
+var currencyCodes = map[string]bool{}
+
+func init() {
+	for _, code := range strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV
+		BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUC CUP CVE
+		CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD
+		HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD
+		KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV
+		MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB
+		RWF SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL THB TJS TMT
+		TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF
+		XCD XOF XPF YER ZAR ZMW ZWL`) {
+		currencyCodes[code] = true
+	}
+}
+
+// IsCurrencyCode tells whether the code is an active ISO 4217 currency code
+// salaries can be paid in.
+func IsCurrencyCode(code string) bool {
+	return currencyCodes[code]
+}
+
+// ParseCurrencyRates parses rates given as a comma separated list of
+// CODE=rate pairs, such as "EUR=1,USD=0.92".
+func ParseCurrencyRates(text string) (map[string]float64, error) {
+	rates := map[string]float64{}
+	for _, pair := range strings.Split(text, ",") {
+		parts := strings.Split(strings.TrimSpace(pair), "=")
+		if len(parts) != 2 || !IsCurrencyCode(parts[0]) {
+			return nil, fmt.Errorf("Invalid currency rate %q", pair)
+		}
+		rate, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("Invalid currency rate %q", pair)
+		}
+		rates[parts[0]] = rate
+	}
+	return rates, nil
+}
+
+// ToYearlyBaseSalary converts the salary to a yearly one in BaseCurrency. It
+// fails when there is no rate for the currency.
+func ToYearlyBaseSalary(amount float64, currency string, period string) (float64, bool) {
+	rate, ok := CurrencyRates[currency]
+	if !ok {
+		return 0, false
+	}
+	periods, ok := SalaryPeriodsPerYear[period]
+	if !ok {
+		return 0, false
+	}
+	return amount * rate * periods, true
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the language the text fields of job offers are written in.
+// Other languages are stored as translations.
+var DefaultLocale = "en"
+
+// SupportedLocales are the languages offers can be translated to and read in.
+var SupportedLocales = []string{"en"}
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// NormalizeLocale lowercases a language tag and separates its subtags with
+// hyphens, so en_US and en-us are the same locale.
+func NormalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
+
+// IsSupportedLocale tells whether the normalized locale is one of SupportedLocales.
+func IsSupportedLocale(locale string) bool {
+	for _, supported := range SupportedLocales {
+		if supported == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLocales parses a comma separated list of language tags.
+func ParseLocales(text string) ([]string, error) {
+	var locales []string
+	for _, item := range strings.Split(text, ",") {
+		locale := NormalizeLocale(item)
+		if !localePattern.MatchString(locale) {
+			return nil, fmt.Errorf("Invalid locale %q", item)
+		}
+		locales = append(locales, locale)
+	}
+	return locales, nil
+}
+
+// NegotiateLocale picks the supported locale to respond in. The lang query
+// parameter wins over the Accept-Language header, and DefaultLocale is used
+// when neither names a supported locale. A tag with a region, such as de-AT,
+// matches its language when only the language is supported.
+func NegotiateLocale(lang string, acceptLanguage string) string {
+	wanted := acceptedLocales(acceptLanguage)
+	if lang != "" {
+		wanted = append([]string{NormalizeLocale(lang)}, wanted...)
+	}
+
+	for _, locale := range wanted {
+		if IsSupportedLocale(locale) {
+			return locale
+		}
+		if language := strings.SplitN(locale, "-", 2)[0]; IsSupportedLocale(language) {
+			return language
+		}
+	}
+	return DefaultLocale
+}
+
+// acceptedLocales returns the languages of an Accept-Language header, most
+// preferred first. Languages with a quality of zero and the wildcard are left out.
+func acceptedLocales(header string) []string {
+	type accepted struct {
+		locale  string
+		quality float64
+	}
+
+	var languages []accepted
+	for _, item := range strings.Split(header, ",") {
+		parts := strings.Split(item, ";")
+		locale := NormalizeLocale(parts[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if value, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = value
+				}
+			}
+		}
+		if quality > 0 {
+			languages = append(languages, accepted{locale, quality})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+	locales := make([]string, len(languages))
+	for i, language := range languages {
+		locales[i] = language.locale
+	}
+	return locales
+}
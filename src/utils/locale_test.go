@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocaleUnitTestsSuite struct {
+	suite.Suite
+	supportedLocales []string
+}
+
+func TestLocaleUnitTestsSuite(t *testing.T) {
+	suite.Run(t, new(LocaleUnitTestsSuite))
+}
+
+func (suite *LocaleUnitTestsSuite) SetupTest() {
+	suite.supportedLocales = SupportedLocales
+	SupportedLocales = []string{"en", "de", "sr-latn"}
+}
+
+func (suite *LocaleUnitTestsSuite) TearDownTest() {
+	SupportedLocales = suite.supportedLocales
+}
+
+func (suite *LocaleUnitTestsSuite) TestNegotiateLocale() {
+	tests := []struct {
+		lang           string
+		acceptLanguage string
+		expected       string
+	}{
+		{"", "", "en"},
+		{"de", "", "de"},
+		{"DE", "", "de"},
+		{"fr", "de", "de"},
+		{"de", "sr-Latn", "de"},
+		{"", "fr, de;q=0.5, en;q=0.8", "en"},
+		{"", "de;q=0.2, sr_LATN;q=0.9", "sr-latn"},
+		{"", "de-AT", "de"},
+		{"", "sr-Latn-RS", "en"},
+		{"", "de;q=0, fr", "en"},
+		{"", "*, de;q=0.1", "de"},
+		{"", "de;q=abc", "de"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(suite.T(), test.expected, NegotiateLocale(test.lang, test.acceptLanguage), "lang %q, Accept-Language %q", test.lang, test.acceptLanguage)
+	}
+}
+
+func (suite *LocaleUnitTestsSuite) TestAcceptedLocales() {
+	assert.Equal(suite.T(), []string{"fr", "de-at", "en"}, acceptedLocales("en;q=0.3, fr, de_AT;q=0.7, *;q=0.9, it;q=0"))
+	assert.Empty(suite.T(), acceptedLocales(""))
+}
+
+func (suite *LocaleUnitTestsSuite) TestParseLocales() {
+	locales, err := ParseLocales("en, DE_at")
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), []string{"en", "de-at"}, locales)
+
+	_, err = ParseLocales("en,,de")
+	assert.NotNil(suite.T(), err)
+}
@@ -0,0 +1,56 @@
+package utils
+
+import "strings"
+
+// skillAliases maps common alternative spellings to the canonical skill name.
+var skillAliases = map[string]string{
+	"golang":     "go",
+	"js":         "javascript",
+	"ts":         "typescript",
+	"postgres":   "postgresql",
+	"k8s":        "kubernetes",
+	"nodejs":     "node.js",
+	"node":       "node.js",
+	"reactjs":    "react",
+	"react.js":   "react",
+	"c sharp":    "c#",
+	"csharp":     "c#",
+	"cplusplus":  "c++",
+	"py":         "python",
+	"python3":    "python",
+	"ml":         "machine learning",
+	"vuejs":      "vue",
+	"vue.js":     "vue",
+	"springboot": "spring boot",
+}
+
+// NormalizeSkill returns the canonical name of a skill, so that "Golang"
+// and "go" are stored as the same skill.
+func NormalizeSkill(name string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	if alias, ok := skillAliases[normalized]; ok {
+		return alias
+	}
+	return normalized
+}
+
+// NormalizeSkills normalizes every skill and drops empty and duplicate ones,
+// keeping the original order.
+func NormalizeSkills(names []string) []string {
+	seen := map[string]bool{}
+	skills := []string{}
+	for _, name := range names {
+		skill := NormalizeSkill(name)
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		skills = append(skills, skill)
+	}
+	return skills
+}
+
+// ParseSkills splits a comma separated list of skills.
+func ParseSkills(text string) []string {
+	return NormalizeSkills(strings.Split(text, ","))
+}